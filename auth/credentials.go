@@ -0,0 +1,35 @@
+// Package auth holds the credential checks shared by the JWT login and the middlewares,
+// so every login form handles unknown users and Authorization headers the same way.
+package auth
+
+import (
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// compared against for unknown users so response time doesn't reveal which users exist
+var dummyHash, _ = bcrypt.GenerateFromPassword([]byte("dummy-password"), bcrypt.DefaultCost)
+
+// CheckPassword reports whether password matches user's bcrypt hash in accounts.
+// Unknown users cost a bcrypt comparison too, against a dummy hash.
+func CheckPassword(accounts gin.Accounts, user, password string) bool {
+	hash, found := accounts[user]
+	if !found {
+		hash = string(dummyHash)
+	}
+	err := bcrypt.CompareHashAndPassword([]byte(hash), []byte(password))
+	return found && err == nil
+}
+
+// BearerToken turns "Authorization: Bearer <token>" into "<token>" (prefix is case-insensitive).
+// Returns "" when the prefix is missing or nothing follows it.
+func BearerToken(authorization string) string {
+	authorization = strings.TrimSpace(authorization)
+	const prefix = "bearer "
+	if len(authorization) < len(prefix) || !strings.EqualFold(authorization[:len(prefix)], prefix) {
+		return ""
+	}
+	return strings.TrimSpace(authorization[len(prefix):])
+}
@@ -0,0 +1,61 @@
+package auth
+
+import (
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"golang.org/x/crypto/bcrypt"
+)
+
+func TestBearerToken(t *testing.T) {
+	tests := []struct {
+		name          string
+		authorization string
+		want          string
+	}{
+		{"canonical", "Bearer secret", "secret"},
+		{"lowercase scheme", "bearer secret", "secret"},
+		{"uppercase scheme", "BEARER secret", "secret"},
+		{"extra spaces", "  Bearer    secret  ", "secret"},
+		{"missing prefix", "secret", ""},
+		{"other scheme", "Basic dXNlcjpwYXNz", ""},
+		{"prefix without space", "Bearersecret", ""},
+		{"empty after prefix", "Bearer ", ""},
+		{"only spaces after prefix", "Bearer    ", ""},
+		{"empty header", "", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := BearerToken(tt.authorization); got != tt.want {
+				t.Errorf("BearerToken(%q) = %q, want %q", tt.authorization, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCheckPassword(t *testing.T) {
+	hash, err := bcrypt.GenerateFromPassword([]byte("p1"), bcrypt.MinCost)
+	if err != nil {
+		t.Fatal(err)
+	}
+	accounts := gin.Accounts{"u": string(hash), "broken": "not a hash"}
+
+	tests := []struct {
+		name, user, password string
+		want                 bool
+	}{
+		{"right password", "u", "p1", true},
+		{"wrong password", "u", "p2", false},
+		{"empty password", "u", "", false},
+		{"unknown user", "nobody", "p1", false},
+		{"unknown user with the dummy password", "nobody", "dummy-password", false},
+		{"stored value isn't a hash", "broken", "not a hash", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := CheckPassword(accounts, tt.user, tt.password); got != tt.want {
+				t.Errorf("CheckPassword(%q, %q) = %v, want %v", tt.user, tt.password, got, tt.want)
+			}
+		})
+	}
+}
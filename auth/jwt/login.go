@@ -0,0 +1,59 @@
+package jwt
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/skyy/gin-gonic/auth"
+)
+
+type loginRequest struct {
+	Username string `json:"username" binding:"required"`
+	Password string `json:"password" binding:"required"`
+}
+
+//💡 LoginHandler trades valid credentials for a token.
+// accounts hold bcrypt hashes (see middlewares.LoadAccounts), roles maps a user to its roles claim.
+// POST /login  {"username":"user","password":"passw"}
+func LoginHandler(accounts gin.Accounts, roles map[string][]string, secret []byte, ttl time.Duration) gin.HandlerFunc {
+	return LoginHandlerFunc(func() gin.Accounts { return accounts }, roles, secret, ttl)
+}
+
+// LoginHandlerFunc is LoginHandler with accounts looked up per request (e.g. middlewares.AccountStore.Get),
+// so reloaded credentials apply to the next login.
+func LoginHandlerFunc(accounts func() gin.Accounts, roles map[string][]string, secret []byte, ttl time.Duration) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		var req loginRequest
+		if err := ctx.ShouldBindJSON(&req); err != nil {
+			ctx.JSON(http.StatusBadRequest, gin.H{
+				"code":    http.StatusBadRequest,
+				"message": "username and password are required",
+			})
+			return
+		}
+
+		if !auth.CheckPassword(accounts(), req.Username, req.Password) {
+			ctx.JSON(http.StatusUnauthorized, gin.H{
+				"code":    http.StatusUnauthorized,
+				"message": "invalid username or password",
+			})
+			return
+		}
+
+		token, err := IssueToken(Claims{Subject: req.Username, Roles: roles[req.Username]}, secret, ttl)
+		if err != nil {
+			ctx.JSON(http.StatusInternalServerError, gin.H{
+				"code":    http.StatusInternalServerError,
+				"message": "could not issue token",
+			})
+			return
+		}
+
+		ctx.JSON(http.StatusOK, gin.H{
+			"token":      token,
+			"token_type": "Bearer",
+			"expires_in": int(ttl.Seconds()),
+		})
+	}
+}
@@ -0,0 +1,56 @@
+package jwt
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/skyy/gin-gonic/auth"
+	"github.com/skyy/gin-gonic/ctxkeys"
+)
+
+// unexported key type, nothing outside this package can overwrite the claims
+type claimsKey struct{}
+
+//💡 JWTAuth validates "Authorization: Bearer <token>" and stores the claims on the context
+// (GetClaims), the subject and roles also as the request's ctxkeys.Principal
+func JWTAuth(secret []byte) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		token := auth.BearerToken(ctx.GetHeader("Authorization"))
+		if token == "" {
+			abortUnauthorized(ctx, ErrMissingToken)
+			return
+		}
+
+		claims, err := ParseToken(token, secret)
+		if err != nil {
+			abortUnauthorized(ctx, err)
+			return
+		}
+
+		ctx.Set(claimsKey{}, claims)
+		roles := claims.Roles
+		if claims.Role != "" {
+			roles = append([]string{claims.Role}, roles...)
+		}
+		ctxkeys.SetPrincipal(ctx, ctxkeys.Principal{ID: claims.Subject, Method: "jwt", Roles: roles})
+		ctx.Next()
+	}
+}
+
+// GetClaims returns the claims set by JWTAuth
+func GetClaims(ctx *gin.Context) (Claims, bool) {
+	v, ok := ctx.Get(claimsKey{})
+	if !ok {
+		return Claims{}, false
+	}
+	claims, ok := v.(Claims)
+	return claims, ok
+}
+
+func abortUnauthorized(ctx *gin.Context, err error) {
+	ctx.Header("WWW-Authenticate", `Bearer error="invalid_token"`)
+	ctx.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
+		"code":    http.StatusUnauthorized,
+		"message": err.Error(),
+	})
+}
@@ -0,0 +1,286 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+//💡 Server config: defaults, optionally a YAML file (LoadFromFile), then env vars on top
+type Config struct {
+	Port         string        `yaml:"port"`
+	AltPorts     []string      `yaml:"alt_ports"`     // tried in order when Port is already in use (ALT_PORTS="8082,8083")
+	ReadTimeout  time.Duration `yaml:"read_timeout"`  // whole request (headers + body)
+	WriteTimeout time.Duration `yaml:"write_timeout"` // whole response
+	// ReadHeaderTimeout bounds how long a client may take to send its headers,
+	// so slowloris-style clients trickling one header byte at a time get cut off
+	ReadHeaderTimeout time.Duration `yaml:"read_header_timeout"`
+	// IdleTimeout closes keep-alive connections with no request in flight,
+	// so idle clients can't pin sockets/goroutines forever
+	IdleTimeout time.Duration `yaml:"idle_timeout"`
+
+	// HTTPS is served only when both are set
+	TLSCert string `yaml:"tls_cert"`
+	TLSKey  string `yaml:"tls_key"`
+
+	// ForceHTTPS redirects plain-HTTP requests to https:// (308), for TLS-terminating proxies that
+	// don't enforce it themselves. The original scheme is read from ProxyProtoHeader.
+	// The redirect always goes to CanonicalHost (e.g. "api.example.com"), never to the
+	// client's Host header, so it's required with ForceHTTPS.
+	ForceHTTPS       bool   `yaml:"force_https"`
+	ProxyProtoHeader string `yaml:"proxy_proto_header"`
+	CanonicalHost    string `yaml:"canonical_host"`
+
+	// H2C serves HTTP/2 without TLS (prior knowledge or Upgrade: h2c) for in-mesh callers.
+	// Plain HTTP/1.1 keeps working. Ignored when TLS is on, HTTP/2 is negotiated there anyway.
+	H2C bool `yaml:"h2c"`
+
+	// Router redirects. A 301/307 to the "fixed" path is handy for browsers, but API
+	// clients that don't follow redirects (or drop the body on POST) just see an
+	// error, so turn these off where strict clients talk to us.
+	RedirectTrailingSlash bool `yaml:"redirect_trailing_slash"` // /admin/get-QryStr/ -> /admin/get-QryStr (gin default: on)
+	RedirectFixedPath     bool `yaml:"redirect_fixed_path"`     // /ADMIN//get-qrystr -> /admin/get-QryStr (gin default: off)
+
+	// PathPrefix is stripped from every request path before routing (PATH_PREFIX="/api/v1"),
+	// for gateways that forward /api/v1/... unchanged. Empty serves the routes at the root.
+	// gin's redirects (RedirectTrailingSlash ...) don't know about it, so their Location lacks the prefix.
+	PathPrefix string `yaml:"path_prefix"`
+
+	UploadDir string `yaml:"upload_dir"`
+	PublicDir string `yaml:"public_dir"` // served at /static
+
+	MaxInFlight int `yaml:"max_in_flight"` // requests served at once, the rest get a 503
+
+	// Request header limits: total size (http.Server.MaxHeaderBytes) and number of header lines
+	MaxHeaderBytes int `yaml:"max_header_bytes"`
+	MaxHeaders     int `yaml:"max_headers"`
+
+	// Load balancer ranges allowed to set X-Forwarded-For (TRUSTED_PROXIES="10.0.0.0/8,192.168.1.2").
+	// Empty means trust nobody, ClientIP is then always the socket's remote address.
+	TrustedProxies []string `yaml:"trusted_proxies"`
+
+	// Basic-auth accounts, user -> bcrypt hash (file only, never from env)
+	Accounts map[string]string `yaml:"accounts"`
+}
+
+func (c Config) TLSEnabled() bool {
+	return c.TLSCert != "" && c.TLSKey != ""
+}
+
+// Addr is the listen address for http.Server, e.g. ":8081"
+func (c Config) Addr() string {
+	return ":" + c.Port
+}
+
+func defaults() Config {
+	return Config{
+		Port:                  "8081",
+		RedirectTrailingSlash: true,
+		ReadTimeout:           10 * time.Second,
+		WriteTimeout:          10 * time.Second,
+		ReadHeaderTimeout:     5 * time.Second,
+		IdleTimeout:           60 * time.Second,
+		UploadDir:             "uploads",
+		PublicDir:             "./public",
+		MaxInFlight:           1000,
+		MaxHeaderBytes:        1 << 20, // 1 MB, same as http.DefaultMaxHeaderBytes
+		MaxHeaders:            100,
+		ProxyProtoHeader:      "X-Forwarded-Proto",
+	}
+}
+
+// Load reads the config from the environment only
+func Load() (Config, error) {
+	cfg := defaults()
+	if err := applyEnv(&cfg); err != nil {
+		return Config{}, err
+	}
+	if err := cfg.Validate(); err != nil {
+		return Config{}, err
+	}
+	return cfg, nil
+}
+
+// applyEnv overrides cfg with every env var that is set, reporting all bad values at once
+func applyEnv(cfg *Config) error {
+	var errs []error
+	collect := func(err error) {
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	cfg.Port = getEnv("PORT", cfg.Port)
+	if alt := listEnv("ALT_PORTS"); len(alt) > 0 {
+		cfg.AltPorts = alt
+	}
+	if proxies := listEnv("TRUSTED_PROXIES"); len(proxies) > 0 {
+		cfg.TrustedProxies = proxies
+	}
+	cfg.TLSCert = getEnv("TLS_CERT", cfg.TLSCert)
+	cfg.TLSKey = getEnv("TLS_KEY", cfg.TLSKey)
+	cfg.ProxyProtoHeader = getEnv("PROXY_PROTO_HEADER", cfg.ProxyProtoHeader)
+	cfg.CanonicalHost = getEnv("CANONICAL_HOST", cfg.CanonicalHost)
+	cfg.PathPrefix = getEnv("PATH_PREFIX", cfg.PathPrefix)
+	cfg.UploadDir = getEnv("UPLOAD_DIR", cfg.UploadDir)
+	cfg.PublicDir = getEnv("PUBLIC_DIR", cfg.PublicDir)
+
+	var err error
+	cfg.ReadTimeout, err = durationEnv("READ_TIMEOUT", cfg.ReadTimeout)
+	collect(err)
+	cfg.WriteTimeout, err = durationEnv("WRITE_TIMEOUT", cfg.WriteTimeout)
+	collect(err)
+	cfg.ReadHeaderTimeout, err = durationEnv("READ_HEADER_TIMEOUT", cfg.ReadHeaderTimeout)
+	collect(err)
+	cfg.IdleTimeout, err = durationEnv("IDLE_TIMEOUT", cfg.IdleTimeout)
+	collect(err)
+
+	cfg.ForceHTTPS, err = boolEnv("FORCE_HTTPS", cfg.ForceHTTPS)
+	collect(err)
+	cfg.H2C, err = boolEnv("H2C", cfg.H2C)
+	collect(err)
+	cfg.MaxInFlight, err = intEnv("MAX_IN_FLIGHT", cfg.MaxInFlight)
+	collect(err)
+	cfg.MaxHeaderBytes, err = intEnv("MAX_HEADER_BYTES", cfg.MaxHeaderBytes)
+	collect(err)
+	cfg.MaxHeaders, err = intEnv("MAX_HEADERS", cfg.MaxHeaders)
+	collect(err)
+
+	cfg.RedirectTrailingSlash, err = boolEnv("REDIRECT_TRAILING_SLASH", cfg.RedirectTrailingSlash)
+	collect(err)
+	cfg.RedirectFixedPath, err = boolEnv("REDIRECT_FIXED_PATH", cfg.RedirectFixedPath)
+	collect(err)
+
+	return errors.Join(errs...)
+}
+
+// Validate reports every problem with the config, not just the first one
+func (c Config) Validate() error {
+	var errs []error
+
+	for _, p := range append([]string{c.Port}, c.AltPorts...) {
+		if n, err := strconv.Atoi(p); err != nil || n < 1 || n > 65535 {
+			errs = append(errs, fmt.Errorf("config: invalid port %q", p))
+		}
+	}
+
+	timeouts := []struct {
+		name string
+		d    time.Duration
+	}{
+		{"read_timeout", c.ReadTimeout},
+		{"write_timeout", c.WriteTimeout},
+		{"read_header_timeout", c.ReadHeaderTimeout},
+		{"idle_timeout", c.IdleTimeout},
+	}
+	for _, t := range timeouts {
+		if t.d <= 0 {
+			errs = append(errs, fmt.Errorf("config: %s must be positive, got %s", t.name, t.d))
+		}
+	}
+
+	limits := []struct {
+		name string
+		n    int
+	}{
+		{"max_in_flight", c.MaxInFlight},
+		{"max_header_bytes", c.MaxHeaderBytes},
+		{"max_headers", c.MaxHeaders},
+	}
+	for _, l := range limits {
+		if l.n < 1 {
+			errs = append(errs, fmt.Errorf("config: %s must be positive, got %d", l.name, l.n))
+		}
+	}
+
+	for _, p := range c.TrustedProxies {
+		if _, _, err := net.ParseCIDR(p); err != nil && net.ParseIP(p) == nil {
+			errs = append(errs, fmt.Errorf("config: invalid trusted proxy %q (want an IP or CIDR)", p))
+		}
+	}
+
+	if c.PathPrefix != "" && !strings.HasPrefix(c.PathPrefix, "/") {
+		errs = append(errs, fmt.Errorf("config: path_prefix must start with /, got %q", c.PathPrefix))
+	}
+
+	if c.ForceHTTPS && c.CanonicalHost == "" {
+		errs = append(errs, errors.New("config: force_https needs canonical_host to redirect to"))
+	}
+	if strings.ContainsAny(c.CanonicalHost, "/?#@") {
+		errs = append(errs, fmt.Errorf("config: canonical_host must be a bare host[:port], got %q", c.CanonicalHost))
+	}
+
+	if (c.TLSCert == "") != (c.TLSKey == "") {
+		errs = append(errs, errors.New("config: tls_cert and tls_key must be set together"))
+	}
+
+	for user, hash := range c.Accounts {
+		if _, err := bcrypt.Cost([]byte(hash)); err != nil {
+			errs = append(errs, fmt.Errorf("config: account %q: password must be a bcrypt hash", user))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func getEnv(key, def string) string {
+	if v, ok := os.LookupEnv(key); ok && v != "" {
+		return v
+	}
+	return def
+}
+
+// comma separated, blanks dropped
+func listEnv(key string) []string {
+	var out []string
+	for _, v := range strings.Split(os.Getenv(key), ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			out = append(out, v)
+		}
+	}
+	return out
+}
+
+// booleans use strconv.ParseBool syntax: true/false, 1/0 ...
+func boolEnv(key string, def bool) (bool, error) {
+	v, ok := os.LookupEnv(key)
+	if !ok || v == "" {
+		return def, nil
+	}
+	b, err := strconv.ParseBool(v)
+	if err != nil {
+		return false, fmt.Errorf("config: invalid %s %q (want true or false): %w", key, v, err)
+	}
+	return b, nil
+}
+
+func intEnv(key string, def int) (int, error) {
+	v, ok := os.LookupEnv(key)
+	if !ok || v == "" {
+		return def, nil
+	}
+	n, err := strconv.Atoi(v)
+	if err != nil {
+		return 0, fmt.Errorf("config: invalid %s %q (want a whole number): %w", key, v, err)
+	}
+	return n, nil
+}
+
+// durations use time.ParseDuration syntax, e.g. "500ms", "15s", "2m"
+func durationEnv(key string, def time.Duration) (time.Duration, error) {
+	v, ok := os.LookupEnv(key)
+	if !ok || v == "" {
+		return def, nil
+	}
+	d, err := time.ParseDuration(v)
+	if err != nil {
+		return 0, fmt.Errorf("config: invalid %s %q (want a duration like \"10s\"): %w", key, v, err)
+	}
+	return d, nil
+}
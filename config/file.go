@@ -0,0 +1,36 @@
+package config
+
+import (
+	"fmt"
+	"os"
+
+	"gopkg.in/yaml.v3"
+)
+
+//💡 LoadFromFile reads a per-environment YAML file, then lets env vars override it.
+//
+//	port: "8081"
+//	read_timeout: 10s
+//	write_timeout: 10s
+//	tls_cert: /etc/certs/cert.pem
+//	tls_key: /etc/certs/key.pem
+//	accounts:
+//	  user: $2y$10$...   # bcrypt, e.g. from `htpasswd -nbB user passw`
+func LoadFromFile(path string) (Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Config{}, fmt.Errorf("config: reading %s: %w", path, err)
+	}
+
+	cfg := defaults()
+	if err := yaml.Unmarshal(data, &cfg); err != nil {
+		return Config{}, fmt.Errorf("config: parsing %s: %w", path, err)
+	}
+	if err := applyEnv(&cfg); err != nil {
+		return Config{}, err
+	}
+	if err := cfg.Validate(); err != nil {
+		return Config{}, err
+	}
+	return cfg, nil
+}
@@ -0,0 +1,129 @@
+package ctxkeys
+
+import "github.com/gin-gonic/gin"
+
+// key is unexported, so no other package can collide with (or overwrite) these entries
+// the way two middlewares both using the string "user" would
+type key int
+
+const (
+	requestIDKey key = iota
+	principalKey
+	routeKey
+	tenantKey
+	negotiatedTypeKey
+	requestBodyKey
+	responseBodyKey
+)
+
+// get reads k as a T, ok=false when it's missing (or, which only a bug in here could cause, of another type)
+func get[T any](ctx *gin.Context, k key) (T, bool) {
+	v, _ := ctx.Get(k)
+	t, ok := v.(T)
+	return t, ok
+}
+
+//💡 Principal is who the request was authenticated as, whichever auth middleware did it
+type Principal struct {
+	ID     string   // user name, JWT subject or API key owner
+	Method string   // "jwt", "api_key" or "basic"
+	Roles  []string // only JWTs carry roles
+}
+
+func SetPrincipal(ctx *gin.Context, p Principal) {
+	ctx.Set(principalKey, p)
+}
+
+// GetPrincipal returns the principal an auth middleware set, ok=false for anonymous requests
+func GetPrincipal(ctx *gin.Context) (Principal, bool) {
+	return get[Principal](ctx, principalKey)
+}
+
+func SetRequestID(ctx *gin.Context, id string) {
+	ctx.Set(requestIDKey, id)
+}
+
+// GetRequestID returns the id set by middlewares.RequestID, or "" if it didn't run
+func GetRequestID(ctx *gin.Context) string {
+	id, _ := get[string](ctx, requestIDKey)
+	return id
+}
+
+// RequestIDFrom is GetRequestID for log formatters, which only get the context's Keys
+func RequestIDFrom(keys map[any]any) string {
+	id, _ := keys[requestIDKey].(string)
+	return id
+}
+
+func SetRoute(ctx *gin.Context, route string) {
+	ctx.Set(routeKey, route)
+}
+
+// GetRoute returns the route template kept by middlewares.CaptureRoute, or "" if it didn't run
+func GetRoute(ctx *gin.Context) string {
+	route, _ := get[string](ctx, routeKey)
+	return route
+}
+
+// RouteFrom is GetRoute for log formatters
+func RouteFrom(keys map[any]any) string {
+	route, _ := keys[routeKey].(string)
+	return route
+}
+
+func SetTenant(ctx *gin.Context, tenant string) {
+	ctx.Set(tenantKey, tenant)
+}
+
+// GetTenant returns the tenant set by middlewares.TenantResolver, or "" if it didn't run
+func GetTenant(ctx *gin.Context) string {
+	tenant, _ := get[string](ctx, tenantKey)
+	return tenant
+}
+
+// TenantFrom is GetTenant for log formatters
+func TenantFrom(keys map[any]any) string {
+	tenant, _ := keys[tenantKey].(string)
+	return tenant
+}
+
+func SetNegotiatedType(ctx *gin.Context, mediaType string) {
+	ctx.Set(negotiatedTypeKey, mediaType)
+}
+
+// GetNegotiatedType returns the type middlewares.AcceptFilter chose, or "" if it didn't run
+func GetNegotiatedType(ctx *gin.Context) string {
+	mt, _ := get[string](ctx, negotiatedTypeKey)
+	return mt
+}
+
+// SetRequestBody / SetResponseBody keep the (capped, redacted) bodies middlewares.BodyLogger
+// captured for the JSON access log
+func SetRequestBody(ctx *gin.Context, body string) {
+	ctx.Set(requestBodyKey, body)
+}
+
+func SetResponseBody(ctx *gin.Context, body string) {
+	ctx.Set(responseBodyKey, body)
+}
+
+// GetRequestBody returns the captured request body, ok=false when none was captured
+func GetRequestBody(ctx *gin.Context) (string, bool) {
+	return get[string](ctx, requestBodyKey)
+}
+
+// GetResponseBody returns the captured response body, ok=false when none was captured
+func GetResponseBody(ctx *gin.Context) (string, bool) {
+	return get[string](ctx, responseBodyKey)
+}
+
+// RequestBodyFrom / ResponseBodyFrom are the getters for log formatters
+func RequestBodyFrom(keys map[any]any) string {
+	body, _ := keys[requestBodyKey].(string)
+	return body
+}
+
+func ResponseBodyFrom(keys map[any]any) string {
+	body, _ := keys[responseBodyKey].(string)
+	return body
+}
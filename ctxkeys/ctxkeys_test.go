@@ -0,0 +1,114 @@
+package ctxkeys
+
+import (
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func newContext() *gin.Context {
+	ctx, _ := gin.CreateTestContext(httptest.NewRecorder())
+	return ctx
+}
+
+// every string-valued key: its setter and the getters that read it back as "" when unset
+var stringKeys = []struct {
+	name string
+	key  key
+	set  func(*gin.Context, string)
+	get  func(*gin.Context) string
+	from func(map[any]any) string // nil when there's no formatter getter
+}{
+	{"request id", requestIDKey, SetRequestID, GetRequestID, RequestIDFrom},
+	{"route", routeKey, SetRoute, GetRoute, RouteFrom},
+	{"tenant", tenantKey, SetTenant, GetTenant, TenantFrom},
+	{"negotiated type", negotiatedTypeKey, SetNegotiatedType, GetNegotiatedType, nil},
+	{"request body", requestBodyKey, SetRequestBody, func(ctx *gin.Context) string { b, _ := GetRequestBody(ctx); return b }, RequestBodyFrom},
+	{"response body", responseBodyKey, SetResponseBody, func(ctx *gin.Context) string { b, _ := GetResponseBody(ctx); return b }, ResponseBodyFrom},
+}
+
+func TestStringKeys(t *testing.T) {
+	for _, k := range stringKeys {
+		t.Run(k.name+"/set and get", func(t *testing.T) {
+			ctx := newContext()
+			k.set(ctx, "value-1")
+			if got := k.get(ctx); got != "value-1" {
+				t.Errorf("get = %q, want value-1", got)
+			}
+			if k.from != nil {
+				if got := k.from(ctx.Keys); got != "value-1" {
+					t.Errorf("from(Keys) = %q, want value-1", got)
+				}
+			}
+		})
+		t.Run(k.name+"/missing", func(t *testing.T) {
+			ctx := newContext()
+			if got := k.get(ctx); got != "" {
+				t.Errorf("get = %q, want empty", got)
+			}
+			if k.from != nil {
+				if got := k.from(nil); got != "" {
+					t.Errorf("from(nil) = %q, want empty", got)
+				}
+			}
+		})
+		t.Run(k.name+"/wrong type", func(t *testing.T) {
+			ctx := newContext()
+			ctx.Set(k.key, 42)
+			if got := k.get(ctx); got != "" {
+				t.Errorf("get = %q, want empty for a non-string value", got)
+			}
+			if k.from != nil {
+				if got := k.from(ctx.Keys); got != "" {
+					t.Errorf("from(Keys) = %q, want empty for a non-string value", got)
+				}
+			}
+		})
+		t.Run(k.name+"/string key doesn't collide", func(t *testing.T) {
+			ctx := newContext()
+			k.set(ctx, "value-1")
+			ctx.Set("route", "spoofed") // what a middleware using plain strings might do
+			ctx.Set("tenant_id", "spoofed")
+			ctx.Set("request_id", "spoofed")
+			if got := k.get(ctx); got != "value-1" {
+				t.Errorf("get = %q, want value-1", got)
+			}
+		})
+	}
+}
+
+func TestBodyOK(t *testing.T) {
+	ctx := newContext()
+	if _, ok := GetRequestBody(ctx); ok {
+		t.Error("GetRequestBody ok before anything was captured")
+	}
+	SetRequestBody(ctx, "") // an empty body was captured, which isn't the same as none
+	if _, ok := GetRequestBody(ctx); !ok {
+		t.Error("GetRequestBody not ok for a captured empty body")
+	}
+	ctx.Set(responseBodyKey, []byte("raw"))
+	if _, ok := GetResponseBody(ctx); ok {
+		t.Error("GetResponseBody ok for a non-string value")
+	}
+}
+
+func TestPrincipal(t *testing.T) {
+	ctx := newContext()
+	if _, ok := GetPrincipal(ctx); ok {
+		t.Error("GetPrincipal ok on an anonymous request")
+	}
+
+	want := Principal{ID: "alice", Method: "jwt", Roles: []string{"admin"}}
+	SetPrincipal(ctx, want)
+	got, ok := GetPrincipal(ctx)
+	if !ok || !reflect.DeepEqual(got, want) {
+		t.Errorf("GetPrincipal = %+v, %v, want %+v, true", got, ok, want)
+	}
+
+	ctx.Set(principalKey, &want) // a pointer isn't a Principal
+	if _, ok := GetPrincipal(ctx); ok {
+		t.Error("GetPrincipal ok for a *Principal")
+	}
+}
@@ -0,0 +1,82 @@
+package handlers
+
+import (
+	"mime"
+	"net/http"
+	"path"
+	"regexp"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/skyy/gin-gonic/middlewares"
+)
+
+// app.3f2a9c1b.js (webpack), index-BxW3_3kK.css (vite): the hash has to contain a digit so
+// plain names like jquery-bootstrap.js don't count
+var fingerprint = regexp.MustCompile(`[.-]([A-Za-z0-9_]{8,})\.[A-Za-z0-9]+$`)
+
+func isFingerprinted(name string) bool {
+	m := fingerprint.FindStringSubmatch(path.Base(name))
+	return m != nil && strings.ContainsAny(m[1], "0123456789")
+}
+
+// best first, the file on disk is name + ext
+var precompressed = []struct{ encoding, ext string }{
+	{"br", ".br"},
+	{"gzip", ".gz"},
+}
+
+//💡 Assets serves built front-end files from dir (route: /assets/*filepath).
+// Fingerprinted files are cached for a year (immutable: the name changes with the content),
+// anything else is revalidated. A .br / .gz sibling is sent instead of the file when the
+// client accepts it, otherwise the global Gzip mw compresses on the fly.
+func Assets(dir string) gin.HandlerFunc {
+	fs := http.Dir(dir) // Open rejects ../ escapes
+
+	return func(ctx *gin.Context) {
+		name := path.Clean("/" + ctx.Param("filepath"))
+
+		f, err := fs.Open(name)
+		if err != nil {
+			AbortWithError(ctx, http.StatusNotFound, "asset not found")
+			return
+		}
+		defer f.Close()
+		info, err := f.Stat()
+		if err != nil || info.IsDir() {
+			AbortWithError(ctx, http.StatusNotFound, "asset not found")
+			return
+		}
+
+		h := ctx.Writer.Header()
+		if isFingerprinted(name) {
+			h.Set("Cache-Control", "public, max-age=31536000, immutable")
+		} else {
+			h.Set("Cache-Control", "public, no-cache")
+		}
+		h.Add("Vary", "Accept-Encoding")
+
+		accept := ctx.GetHeader("Accept-Encoding")
+		for _, p := range precompressed {
+			if !middlewares.AcceptsEncoding(accept, p.encoding) {
+				continue
+			}
+			cf, err := fs.Open(name + p.ext)
+			if err != nil {
+				continue
+			}
+			defer cf.Close()
+			if ci, err := cf.Stat(); err == nil && !ci.IsDir() {
+				// Content-Type of the original, ServeContent would sniff the compressed bytes
+				if ct := mime.TypeByExtension(path.Ext(name)); ct != "" {
+					h.Set("Content-Type", ct)
+				}
+				h.Set("Content-Encoding", p.encoding) // the Gzip mw leaves encoded bodies alone
+				http.ServeContent(ctx.Writer, ctx.Request, name, ci.ModTime(), cf)
+				return
+			}
+		}
+
+		http.ServeContent(ctx.Writer, ctx.Request, name, info.ModTime(), f)
+	}
+}
@@ -0,0 +1,113 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+)
+
+// FieldError is one client-facing problem with a request body, Field is "" for the body as a whole
+type FieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+// report json names ("age") rather than Go field names ("Age") in validation errors
+func init() {
+	if v, ok := binding.Validator.Engine().(*validator.Validate); ok {
+		v.RegisterTagNameFunc(func(f reflect.StructField) string {
+			name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+			if name == "-" {
+				return ""
+			}
+			if name == "" {
+				return f.Name
+			}
+			return name
+		})
+	}
+}
+
+//💡 PrettyBindError turns a ShouldBindJSON error into messages a client can act on,
+// e.g. "age must be a number" instead of "json: cannot unmarshal string into Go struct field ...".
+func PrettyBindError(err error) []FieldError {
+	var verrs validator.ValidationErrors
+	if errors.As(err, &verrs) {
+		out := make([]FieldError, 0, len(verrs))
+		for _, fe := range verrs {
+			out = append(out, FieldError{Field: fe.Field(), Message: validationMessage(fe)})
+		}
+		return out
+	}
+
+	var typeErr *json.UnmarshalTypeError
+	if errors.As(err, &typeErr) {
+		if typeErr.Field == "" {
+			return []FieldError{{Message: "request body must be " + jsonKind(typeErr.Type)}}
+		}
+		return []FieldError{{Field: typeErr.Field, Message: fmt.Sprintf("%s must be %s", typeErr.Field, jsonKind(typeErr.Type))}}
+	}
+
+	var syntaxErr *json.SyntaxError
+	switch {
+	case errors.Is(err, io.EOF):
+		return []FieldError{{Message: "request body is empty"}}
+	case errors.Is(err, io.ErrUnexpectedEOF):
+		return []FieldError{{Message: "request body is incomplete JSON"}}
+	case errors.As(err, &syntaxErr):
+		return []FieldError{{Message: fmt.Sprintf("request body is not valid JSON (at byte %d)", syntaxErr.Offset)}}
+	}
+	return []FieldError{{Message: err.Error()}}
+}
+
+//💡 BindPartial decodes a JSON object body into dst, keyed by field, so a PATCH handler can
+// tell an omitted field (no key) from one explicitly set to its zero value ("age": 0).
+// Errors read well through PrettyBindError.
+func BindPartial(ctx *gin.Context, dst *map[string]json.RawMessage) error {
+	if ctx.Request.Body == nil {
+		return io.EOF
+	}
+	return json.NewDecoder(ctx.Request.Body).Decode(dst)
+}
+
+func validationMessage(fe validator.FieldError) string {
+	switch fe.Tag() {
+	case "required":
+		return fe.Field() + " is required"
+	case "gte", "min":
+		return fmt.Sprintf("%s must be at least %s", fe.Field(), fe.Param())
+	case "lte", "max":
+		return fmt.Sprintf("%s must be at most %s", fe.Field(), fe.Param())
+	case "oneof":
+		return fmt.Sprintf("%s must be one of %s", fe.Field(), strings.ReplaceAll(fe.Param(), " ", ", "))
+	case "email":
+		return fe.Field() + " must be a valid email address"
+	}
+	return fmt.Sprintf("%s failed the %q rule", fe.Field(), fe.Tag())
+}
+
+// the JSON word for a Go type, for "x must be a number" style messages
+func jsonKind(t reflect.Type) string {
+	switch t.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return "a number"
+	case reflect.String:
+		return "a string"
+	case reflect.Bool:
+		return "true or false"
+	case reflect.Slice, reflect.Array:
+		return "an array"
+	case reflect.Map, reflect.Struct:
+		return "an object"
+	}
+	return "a " + t.String()
+}
@@ -0,0 +1,65 @@
+package handlers
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"syscall"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+)
+
+// Read data from the body
+// GET
+func GetBodyDataHandler(ctx *gin.Context) {
+	val, err := io.ReadAll(ctx.Request.Body)
+	if err != nil {
+		// recorded for ErrorCollector to render (and for the access log's error field)
+		var tooLarge *http.MaxBytesError
+		switch {
+		case errors.As(err, &tooLarge):
+			ctx.Error(APIError{Code: http.StatusRequestEntityTooLarge, Message: "request body too large"})
+		case errors.Is(err, io.ErrUnexpectedEOF) || errors.Is(err, syscall.ECONNRESET):
+			// client hung up mid-body -> their fault, not ours
+			ctx.Error(APIError{Code: http.StatusBadRequest, Message: "incomplete request body"})
+		default:
+			// just respond, never log.Fatal here: one bad request must not kill the server
+			ctx.Error(APIError{Code: http.StatusInternalServerError, Message: "failed to read request body", Details: err.Error()})
+		}
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"bodyData": string(val),
+		"status":   http.StatusOK,
+	})
+}
+
+// UserPayload is the typed body for POST /post-body-data
+type UserPayload struct {
+	Name string `json:"name" binding:"required"`
+	Age  *int   `json:"age" binding:"required,gte=0,lte=150"` // pointer, so required means present and 0 is a valid age
+}
+
+// Binding + validating the JSON body (instead of blindly echoing it)
+// POST
+func PostBodyDataHandler(ctx *gin.Context) {
+	var payload UserPayload
+	if err := ctx.ShouldBindJSON(&payload); err != nil {
+		var verrs validator.ValidationErrors
+		if errors.As(err, &verrs) {
+			AbortWithError(ctx, http.StatusUnprocessableEntity, "validation failed", PrettyBindError(err))
+			return
+		}
+
+		// not even valid JSON (or the wrong types in it)
+		AbortWithError(ctx, http.StatusBadRequest, "invalid request body", PrettyBindError(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"data":   payload,
+		"status": http.StatusOK,
+	})
+}
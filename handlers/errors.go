@@ -0,0 +1,78 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/skyy/gin-gonic/i18n"
+)
+
+//💡 APIError is the one error shape every error response uses
+type APIError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+	Details any    `json:"details,omitempty"`
+}
+
+func (e APIError) Error() string {
+	return e.Message
+}
+
+// AbortWithError stops the chain and renders an APIError (details is optional).
+// msg is translated into the client's Accept-Language when there's a translation for it.
+func AbortWithError(ctx *gin.Context, code int, msg string, details ...any) {
+	e := APIError{Code: code, Message: T(ctx, msg)}
+	if len(details) > 0 {
+		e.Details = details[0]
+	}
+	ctx.AbortWithStatusJSON(code, e)
+}
+
+//💡 ErrorCollector renders what handlers record with ctx.Error instead of each handler
+// writing its own error body:
+//
+//	ctx.Error(APIError{Code: http.StatusBadRequest, Message: "incomplete request body"})
+//	return
+//
+// After the chain it answers with the first APIError (a plain error is a 500 whose text stays
+// in the logs only). Several errors are all listed in details. Nothing happens when the
+// handler already wrote a response. Register it as the last global middleware.
+func ErrorCollector() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		ctx.Next()
+
+		if len(ctx.Errors) == 0 || ctx.Writer.Written() {
+			return
+		}
+
+		e := APIError{Code: http.StatusInternalServerError, Message: "internal server error"}
+		var messages []string
+		found := false
+		for _, ge := range ctx.Errors {
+			var apiErr APIError
+			if !errors.As(ge.Err, &apiErr) {
+				continue
+			}
+			if !found {
+				e, found = apiErr, true
+			}
+			messages = append(messages, T(ctx, apiErr.Message))
+		}
+		e.Message = T(ctx, e.Message)
+		if len(messages) > 1 {
+			e.Details = messages
+		}
+		ctx.AbortWithStatusJSON(e.Code, e)
+	}
+}
+
+// Lang is the client's preferred supported language, from Accept-Language
+func Lang(ctx *gin.Context) string {
+	return i18n.Negotiate(ctx.GetHeader("Accept-Language"))
+}
+
+// T translates key for this request, e.g. T(ctx, "route %s not found", path)
+func T(ctx *gin.Context, key string, args ...any) string {
+	return i18n.Translate(Lang(ctx), key, args...)
+}
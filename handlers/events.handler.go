@@ -0,0 +1,39 @@
+package handlers
+
+import (
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+const heartbeatInterval = 5 * time.Second
+
+//💡 Server-Sent Events: pushes a heartbeat every few seconds until the client goes away
+// GET /client/events
+func EventsHandler(ctx *gin.Context) {
+	ctx.Header("Content-Type", "text/event-stream")
+	ctx.Header("Cache-Control", "no-cache")
+	ctx.Header("Connection", "keep-alive")
+	ctx.Header("X-Accel-Buffering", "no") // stop nginx & co. from buffering the stream
+
+	// the server's WriteTimeout would cut the stream, push the deadline out per event instead
+	rc := http.NewResponseController(ctx.Writer)
+	rc.SetWriteDeadline(time.Now().Add(2 * heartbeatInterval))
+
+	ticker := time.NewTicker(heartbeatInterval)
+	defer ticker.Stop()
+
+	ctx.SSEvent("connected", gin.H{"time": time.Now().Unix()})
+	ctx.Stream(func(w io.Writer) bool {
+		select {
+		case <-ctx.Request.Context().Done():
+			return false
+		case t := <-ticker.C:
+			rc.SetWriteDeadline(time.Now().Add(2 * heartbeatInterval))
+			ctx.SSEvent("heartbeat", gin.H{"time": t.Unix()})
+			return true
+		}
+	})
+}
@@ -0,0 +1,54 @@
+package handlers
+
+import (
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+//💡 NoRoute: unknown paths get our APIError shape instead of gin's plain 404
+func NotFoundHandler(ctx *gin.Context) {
+	AbortWithError(ctx, http.StatusNotFound, T(ctx, "route %s not found", ctx.Request.URL.Path))
+}
+
+//💡 NoMethod: 405 plus an Allow header listing the methods the path does support.
+// Needs router.HandleMethodNotAllowed = true.
+func MethodNotAllowedHandler(router *gin.Engine) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		seen := map[string]bool{}
+		for _, r := range router.Routes() {
+			if matchRoute(r.Path, ctx.Request.URL.Path) {
+				seen[r.Method] = true
+			}
+		}
+		allow := make([]string, 0, len(seen))
+		for m := range seen {
+			allow = append(allow, m)
+		}
+		sort.Strings(allow)
+
+		ctx.Header("Allow", strings.Join(allow, ", "))
+		AbortWithError(ctx, http.StatusMethodNotAllowed, T(ctx, "method %s not allowed", ctx.Request.Method), gin.H{"allow": allow})
+	}
+}
+
+// matchRoute reports whether path fits a gin route template ("/users/:id", "/static/*filepath")
+func matchRoute(pattern, path string) bool {
+	ps := strings.Split(strings.Trim(pattern, "/"), "/")
+	xs := strings.Split(strings.Trim(path, "/"), "/")
+
+	for i, p := range ps {
+		if strings.HasPrefix(p, "*") {
+			return true
+		}
+		if i >= len(xs) {
+			return false
+		}
+		if !strings.HasPrefix(p, ":") && p != xs[i] {
+			return false
+		}
+	}
+	return len(ps) == len(xs)
+}
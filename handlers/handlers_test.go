@@ -0,0 +1,13 @@
+package handlers
+
+import (
+	"os"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestMain(m *testing.M) {
+	gin.SetMode(gin.TestMode) // no debug route dumps in the test output
+	os.Exit(m.Run())
+}
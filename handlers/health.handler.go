@@ -0,0 +1,38 @@
+package handlers
+
+import (
+	"net/http"
+	"sync/atomic"
+
+	"github.com/gin-gonic/gin"
+)
+
+var ready atomic.Bool
+
+// SetReady flips /readyz between 503 (starting/stopping) and 200
+func SetReady(v bool) {
+	ready.Store(v)
+}
+
+// Liveness probe for the load balancer (no auth)
+// GET /healthz
+func HealthHandler(ctx *gin.Context) {
+	ctx.JSON(http.StatusOK, gin.H{
+		"status": "ok",
+	})
+}
+
+// Readiness probe, 503 until the server has finished startup
+// GET /readyz
+func ReadyHandler(ctx *gin.Context) {
+	if !ready.Load() {
+		ctx.JSON(http.StatusServiceUnavailable, gin.H{
+			"status": "starting",
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"status": "ready",
+	})
+}
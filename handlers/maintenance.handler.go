@@ -0,0 +1,32 @@
+package handlers
+
+import (
+	"net/http"
+	"sync/atomic"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+)
+
+type maintenanceRequest struct {
+	Enabled *bool `json:"enabled" binding:"required"`
+}
+
+//💡 Maintenance mode switch for middlewares.MaintenanceMode
+// GET /admin/maintenance
+// PUT /admin/maintenance  {"enabled": true}
+func MaintenanceHandler(flag *atomic.Bool) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		if ctx.Request.Method == http.MethodPut {
+			var req maintenanceRequest
+			if err := ctx.ShouldBindJSON(&req); err != nil {
+				AbortWithError(ctx, http.StatusBadRequest, "invalid request body", PrettyBindError(err))
+				return
+			}
+			flag.Store(*req.Enabled)
+			logrus.WithField("enabled", *req.Enabled).Warnln("maintenance mode switched via admin endpoint")
+		}
+
+		ctx.JSON(http.StatusOK, gin.H{"enabled": flag.Load()})
+	}
+}
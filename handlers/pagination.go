@@ -0,0 +1,89 @@
+package handlers
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	defaultPage    = 1
+	defaultPerPage = 20
+	maxPerPage     = 100
+)
+
+//💡 Paginate reads ?page=&per_page= (same ctx.Query pattern as GetQryDataHandler).
+// Defaults are page 1 / per_page 20, per_page is capped at 100.
+// A non-numeric, < 1 or out-of-range value returns an error, render it as a 400.
+// e.g. http://localhost:8081/admin/users?page=2&per_page=50
+func Paginate(ctx *gin.Context) (limit, offset int, err error) {
+	page, err := queryPositiveInt(ctx, "page", defaultPage)
+	if err != nil {
+		return 0, 0, err
+	}
+	perPage, err := queryPositiveInt(ctx, "per_page", defaultPerPage)
+	if err != nil {
+		return 0, 0, err
+	}
+	perPage = min(perPage, maxPerPage)
+	if page > math.MaxInt/perPage {
+		// (page-1)*perPage would overflow into a negative offset
+		return 0, 0, fmt.Errorf("page is too large, got %d", page)
+	}
+
+	return perPage, (page - 1) * perPage, nil
+}
+
+func queryPositiveInt(ctx *gin.Context, key string, def int) (int, error) {
+	raw := ctx.Query(key)
+	if raw == "" {
+		return def, nil
+	}
+	n, err := strconv.Atoi(raw)
+	if err != nil || n < 1 {
+		return 0, fmt.Errorf("%s must be a whole number >= 1, got %q", key, raw)
+	}
+	return n, nil
+}
+
+//💡 ParseSort reads ?sort=&order= for list endpoints. sort must be one of allowed
+// (it ends up in ORDER BY once there's a database, so never pass it through unchecked),
+// order is asc (default) or desc, case-insensitive. Without ?sort= field is allowed[0].
+// An invalid value returns an error, render it as a 400.
+// e.g. http://localhost:8081/admin/users?sort=age&order=desc
+func ParseSort(ctx *gin.Context, allowed []string) (field, dir string, err error) {
+	field = ctx.Query("sort")
+	if field == "" && len(allowed) > 0 {
+		field = allowed[0]
+	} else if !slices.Contains(allowed, field) {
+		return "", "", fmt.Errorf("sort must be one of %s, got %q", strings.Join(allowed, ", "), field)
+	}
+
+	switch dir = strings.ToLower(ctx.DefaultQuery("order", "asc")); dir {
+	case "asc", "desc":
+	default:
+		return "", "", fmt.Errorf("order must be asc or desc, got %q", ctx.Query("order"))
+	}
+	return field, dir, nil
+}
+
+// PageEnvelope wraps a page of results with the paging metadata
+type PageEnvelope struct {
+	Data    any `json:"data"`
+	Total   int `json:"total"`
+	Page    int `json:"page"`
+	PerPage int `json:"per_page"`
+}
+
+func NewPageEnvelope(data any, total, limit, offset int) PageEnvelope {
+	return PageEnvelope{
+		Data:    data,
+		Total:   total,
+		Page:    offset/limit + 1,
+		PerPage: limit,
+	}
+}
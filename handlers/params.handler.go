@@ -0,0 +1,77 @@
+package handlers
+
+import (
+	"net/http"
+	"regexp"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+const maxAge = 150
+
+// 1-64 letters, digits, spaces and . _ ' - so control chars and newlines (log forging)
+// never reach the response or the access log
+var safeName = regexp.MustCompile(`^[\p{L}\p{N} ._'-]{1,64}$`)
+
+// Handling query-params
+// http://localhost:8081/admin/get-QryStr?name=Mark&age=30
+// GET
+func GetQryDataHandler(ctx *gin.Context) {
+	name := ctx.Query("name")
+	age := QueryInt(ctx, "age", 0)
+	if ctx.IsAborted() {
+		return
+	}
+
+	Respond(ctx, http.StatusOK, gin.H{
+		"data":   "Getting data from Query-Params 🟢",
+		"name":   name,
+		"age":    age,
+		"status": http.StatusOK,
+	})
+}
+
+// Handling multi-value query-params
+// http://localhost:8081/admin/get-QryArray?tags=go&tags=gin,api
+// GET
+func GetQryArrayHandler(ctx *gin.Context) {
+	raw := ctx.QueryArray("tags") // repeated params only, comma-joined values stay as-is
+	tags := QueryCSV(ctx, "tags")
+	if tags == nil {
+		tags = []string{} // render [] rather than null
+	}
+
+	Respond(ctx, http.StatusOK, gin.H{
+		"data":   "Getting data from multi-value Query-Params 🟢",
+		"raw":    raw,
+		"tags":   tags,
+		"status": http.StatusOK,
+	})
+}
+
+// Handling URL-params
+// http://localhost:8081/get-UrlParams/Skyy/30
+// GET
+func GetUrlDataHandler(ctx *gin.Context) {
+	// Read data from the URL-params
+	name := ctx.Param("name")
+	if !safeName.MatchString(name) {
+		AbortWithError(ctx, http.StatusBadRequest, "name must be 1-64 letters, digits, spaces or . _ ' -")
+		return
+	}
+
+	// age is numeric, so reject things like /get-UrlParams/Skyy/thirty early
+	age, err := strconv.Atoi(ctx.Param("age"))
+	if err != nil || age < 0 || age > maxAge {
+		AbortWithError(ctx, http.StatusBadRequest, "age must be a whole number between 0 and 150")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"data":   "Getting data from URL params 🔵",
+		"name":   name,
+		"age":    age,
+		"status": http.StatusOK,
+	})
+}
@@ -0,0 +1,56 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+//💡 Typed query params: an absent (or empty) param yields def, a present but unparseable one
+// aborts with a 400 APIError. Check ctx.IsAborted() after reading, e.g.
+//
+//	age := QueryInt(ctx, "age", 0)
+//	if ctx.IsAborted() {
+//		return
+//	}
+func QueryInt(ctx *gin.Context, key string, def int) int {
+	return queryValue(ctx, key, def, "a whole number", strconv.Atoi)
+}
+
+// QueryBool accepts strconv.ParseBool syntax: true/false, 1/0, t/f ...
+func QueryBool(ctx *gin.Context, key string, def bool) bool {
+	return queryValue(ctx, key, def, "true or false", strconv.ParseBool)
+}
+
+func queryValue[T any](ctx *gin.Context, key string, def T, want string, parse func(string) (T, error)) T {
+	raw, ok := ctx.GetQuery(key)
+	if !ok || raw == "" {
+		return def
+	}
+	v, err := parse(raw)
+	if err != nil {
+		// don't clobber an earlier 400 when several params are bad
+		if !ctx.IsAborted() {
+			AbortWithError(ctx, http.StatusBadRequest, fmt.Sprintf("%s must be %s, got %q", key, want, raw))
+		}
+		return def
+	}
+	return v
+}
+
+// QueryCSV collects every value of key, splitting comma-joined ones, so
+// ?tags=a&tags=b, ?tags=a,b and ?tags=a,b&tags=c all work. Blanks are dropped.
+func QueryCSV(ctx *gin.Context, key string) []string {
+	var out []string
+	for _, v := range ctx.QueryArray(key) {
+		for _, part := range strings.Split(v, ",") {
+			if part = strings.TrimSpace(part); part != "" {
+				out = append(out, part)
+			}
+		}
+	}
+	return out
+}
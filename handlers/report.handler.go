@@ -0,0 +1,63 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"github.com/skyy/gin-gonic/ctxkeys"
+	"github.com/skyy/gin-gonic/workerpool"
+)
+
+//💡 Example of offloading slow work: the report is built on the worker pool and the
+// client gets a 202 with the job id right away. A full queue sheds load with a 503.
+// POST /admin/reports
+func ReportHandler(pool *workerpool.Pool) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		// the task outlives the request, so copy what it needs now (ctx is reused by gin)
+		jobID := uuid.NewString()
+		principal, _ := ctxkeys.GetPrincipal(ctx)
+		requestedBy := principal.ID
+
+		err := pool.Submit(func() {
+			start := time.Now()
+			time.Sleep(2 * time.Second) // stands in for the real report generation
+			logrus.WithFields(logrus.Fields{
+				"job_id":       jobID,
+				"requested_by": requestedBy,
+				"took":         time.Since(start).String(),
+			}).Infoln("report finished")
+		})
+		if err != nil {
+			ctx.Error(err) // lets ReportFailed tell a full queue from a failure
+			if errors.Is(err, workerpool.ErrQueueFull) {
+				ctx.Header("Retry-After", "5")
+			}
+			AbortWithError(ctx, http.StatusServiceUnavailable, "too busy, try again later")
+			return
+		}
+
+		ctx.JSON(http.StatusAccepted, gin.H{
+			"job_id": jobID,
+			"status": http.StatusAccepted,
+		})
+	}
+}
+
+// ReportFailed is the failure test for a CircuitBreaker in front of ReportHandler: a 5xx,
+// except the 503 for a full queue, that's the pool shedding load rather than failing, and
+// counting it would open the circuit on every burst
+func ReportFailed(ctx *gin.Context) bool {
+	if ctx.Writer.Status() < http.StatusInternalServerError {
+		return false
+	}
+	for _, e := range ctx.Errors {
+		if errors.Is(e.Err, workerpool.ErrQueueFull) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,52 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/skyy/gin-gonic/middlewares"
+	"github.com/skyy/gin-gonic/workerpool"
+)
+
+// a full queue is load shedding and must not open the reports circuit, a broken pool must
+func TestReportBreaker(t *testing.T) {
+	full := workerpool.New(0, 0) // no worker, no queue: every Submit is ErrQueueFull
+	closed := workerpool.New(1, 1)
+	closed.Shutdown(context.Background())
+
+	tests := []struct {
+		name        string
+		pool        *workerpool.Pool
+		wantMessage string // of the second request
+	}{
+		{"full queue keeps the circuit closed", full, "too busy, try again later"},
+		{"closed pool opens it", closed, "reports is unavailable, try again later"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := gin.New()
+			r.POST("/reports",
+				middlewares.CircuitBreaker("reports", middlewares.BreakerOptions{FailureThreshold: 1, Cooldown: time.Hour, IsFailure: ReportFailed}),
+				ReportHandler(tt.pool))
+
+			var w *httptest.ResponseRecorder
+			for range 2 {
+				w = httptest.NewRecorder()
+				r.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/reports", nil))
+			}
+
+			var e APIError
+			if err := json.Unmarshal(w.Body.Bytes(), &e); err != nil {
+				t.Fatalf("body %s: %v", w.Body, err)
+			}
+			if w.Code != http.StatusServiceUnavailable || e.Message != tt.wantMessage {
+				t.Errorf("second request: %d %q, want 503 %q", w.Code, e.Message, tt.wantMessage)
+			}
+		})
+	}
+}
@@ -0,0 +1,55 @@
+package handlers
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/skyy/gin-gonic/ctxkeys"
+)
+
+//💡 Content negotiation: render data as XML / YAML / JSON based on the Accept header (JSON by default),
+// or as the type middlewares.AcceptFilter negotiated when it's in front of the route
+func Respond(ctx *gin.Context, status int, data any) {
+	if negotiated := ctxkeys.GetNegotiatedType(ctx); negotiated != "" {
+		switch negotiated {
+		case "application/xml", "text/xml":
+			ctx.XML(status, data)
+		case "application/yaml", "application/x-yaml":
+			ctx.YAML(status, data)
+		default:
+			ctx.JSON(status, data)
+		}
+		return
+	}
+
+	// no AcceptFilter in front of this route, sniff the header ourselves
+	accept := ctx.GetHeader("Accept")
+	switch {
+	case strings.Contains(accept, "application/xml"), strings.Contains(accept, "text/xml"):
+		ctx.XML(status, data)
+	case strings.Contains(accept, "yaml"):
+		ctx.YAML(status, data)
+	default:
+		ctx.JSON(status, data)
+	}
+}
+
+// Created answers 201 with the new entity and a Location header pointing at it,
+// e.g. Created(ctx, "/admin/users/42", user)
+func Created(ctx *gin.Context, location string, body any) {
+	ctx.Header("Location", location)
+	Respond(ctx, http.StatusCreated, body)
+}
+
+// RequestedPath is the (escaped) path the client sent, without a trailing slash. Unlike
+// ctx.FullPath() or URL.Path it still has the prefix middlewares.StripPrefix removed, so
+// it's the base to build Location headers from.
+func RequestedPath(ctx *gin.Context) string {
+	path := ctx.Request.URL.EscapedPath()
+	if u, err := url.ParseRequestURI(ctx.Request.RequestURI); err == nil {
+		path = u.EscapedPath()
+	}
+	return strings.TrimSuffix(path, "/")
+}
@@ -0,0 +1,32 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ROOT
+// (plain keys, since XML element names can't hold emoji)
+func RootHandler(ctx *gin.Context) {
+	Respond(ctx, http.StatusOK, gin.H{
+		"thought": "Don't take life too seriously, you ain't getting out alive anyways",
+		"data":    "🍸 Welcome to GET root / home router Skyy!",
+		"status":  http.StatusOK,
+	})
+}
+
+// POST / echoes the JSON body it was sent (any JSON value), a quick way to check
+// what a client really posts after binding
+func PostHandler(ctx *gin.Context) {
+	var body any
+	if err := ctx.ShouldBindJSON(&body); err != nil {
+		AbortWithError(ctx, http.StatusBadRequest, "invalid request body", PrettyBindError(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"data":   body,
+		"status": http.StatusOK,
+	})
+}
@@ -0,0 +1,61 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/skyy/gin-gonic/middlewares"
+)
+
+func TestPostHandlerRoute(t *testing.T) {
+	// wired like main.go
+	r := gin.New()
+	r.POST("/", middlewares.RequireContentType("application/json"), PostHandler)
+
+	tests := []struct {
+		name        string
+		contentType string
+		body        string
+		wantStatus  int
+		wantData    any // decoded "data" of a 200
+	}{
+		{"object is echoed", "application/json", `{"name":"skyy","age":30,"tags":["a","b"]}`, http.StatusOK,
+			map[string]any{"name": "skyy", "age": 30.0, "tags": []any{"a", "b"}}},
+		{"array is echoed", "application/json; charset=utf-8", `[1,"two",null]`, http.StatusOK, []any{1.0, "two", nil}},
+		{"unicode is echoed", "application/json", `{"msg":"héllo 🍸"}`, http.StatusOK, map[string]any{"msg": "héllo 🍸"}},
+		{"malformed JSON", "application/json", `{"name":`, http.StatusBadRequest, nil},
+		{"empty body", "application/json", ``, http.StatusBadRequest, nil},
+		{"not JSON at all", "application/json", `name=skyy`, http.StatusBadRequest, nil},
+		{"wrong content type", "text/plain", `{"name":"skyy"}`, http.StatusUnsupportedMediaType, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", tt.contentType)
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d (body %s)", w.Code, tt.wantStatus, w.Body)
+			}
+			var got map[string]any
+			if err := json.Unmarshal(w.Body.Bytes(), &got); err != nil {
+				t.Fatalf("response isn't JSON: %v (%s)", err, w.Body)
+			}
+			if tt.wantStatus != http.StatusOK {
+				if got["code"] != float64(tt.wantStatus) {
+					t.Errorf("error body %v, want code %d", got, tt.wantStatus)
+				}
+				return
+			}
+			if !reflect.DeepEqual(got["data"], tt.wantData) {
+				t.Errorf("data = %#v, want %#v", got["data"], tt.wantData)
+			}
+		})
+	}
+}
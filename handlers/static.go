@@ -0,0 +1,74 @@
+package handlers
+
+import (
+	"bytes"
+	"mime"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+//💡 NoDirListing wraps a FileSystem so directories 404 instead of rendering an index,
+// unless they have their own index.html.
+func NoDirListing(fs http.FileSystem) http.FileSystem {
+	return noDirListingFS{fs}
+}
+
+type noDirListingFS struct {
+	fs http.FileSystem
+}
+
+func (n noDirListingFS) Open(name string) (http.File, error) {
+	f, err := n.fs.Open(name)
+	if err != nil {
+		return nil, err
+	}
+
+	info, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+	if info.IsDir() {
+		index, err := n.fs.Open(path.Join(name, "index.html"))
+		if err != nil {
+			f.Close()
+			return nil, os.ErrNotExist
+		}
+		index.Close()
+	}
+
+	return f, nil
+}
+
+//💡 Favicon serves the icon at path (read once at startup) with a day of caching.
+// Without the file it answers 204, browsers then stop asking instead of logging 404s.
+func Favicon(path string) gin.HandlerFunc {
+	icon, err := os.ReadFile(path)
+	if err != nil {
+		return func(ctx *gin.Context) {
+			ctx.Header("Cache-Control", "public, max-age=86400")
+			ctx.Status(http.StatusNoContent)
+		}
+	}
+
+	var modTime time.Time
+	if info, err := os.Stat(path); err == nil {
+		modTime = info.ModTime()
+	}
+	contentType := mime.TypeByExtension(filepath.Ext(path))
+	if contentType == "" {
+		contentType = "image/x-icon"
+	}
+
+	return func(ctx *gin.Context) {
+		ctx.Header("Content-Type", contentType)
+		ctx.Header("Cache-Control", "public, max-age=86400")
+		// handles HEAD, Range and If-Modified-Since
+		http.ServeContent(ctx.Writer, ctx.Request, path, modTime, bytes.NewReader(icon))
+	}
+}
@@ -0,0 +1,51 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+)
+
+// IsClientGone reports whether the client has disconnected (or the request was cancelled).
+//
+// Long-running handlers should poll it between chunks of work and return as soon as it's true,
+// otherwise an abandoned stream keeps its goroutine (and whatever it holds) alive:
+//
+//	for {
+//		if handlers.IsClientGone(ctx) {
+//			return
+//		}
+//		// ... do a bit of work, write it, flush ...
+//	}
+//
+// If the handler blocks on a channel, select on ctx.Request.Context().Done() instead.
+func IsClientGone(ctx *gin.Context) bool {
+	return ctx.Request.Context().Err() != nil
+}
+
+const progressSteps = 10
+
+//💡 Example of a long-running stream: one chunked line per step, stops early when the client leaves
+// GET /client/progress
+func ProgressHandler(ctx *gin.Context) {
+	ctx.Header("Content-Type", "text/plain; charset=utf-8")
+	ctx.Header("X-Accel-Buffering", "no")
+	ctx.Status(http.StatusOK)
+
+	for step := 1; step <= progressSteps; step++ {
+		if IsClientGone(ctx) {
+			logrus.WithFields(logrus.Fields{
+				"path": ctx.Request.URL.Path,
+				"step": step,
+			}).Infoln("client went away, stopping stream early")
+			return
+		}
+
+		time.Sleep(500 * time.Millisecond) // stands in for real work
+		fmt.Fprintf(ctx.Writer, "step %d/%d done\n", step, progressSteps)
+		ctx.Writer.Flush()
+	}
+}
@@ -0,0 +1,87 @@
+package handlers
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+var (
+	allowedUploadExts = map[string]bool{".png": true, ".jpg": true, ".pdf": true}
+	unsafeNameChars   = regexp.MustCompile(`[^A-Za-z0-9._-]`)
+)
+
+//💡 File upload (multipart field "file"), saved under dir with a sanitized, unique name.
+// POST /admin/upload
+func UploadHandler(dir string, maxSize int64) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		fh, err := ctx.FormFile("file")
+		if err != nil {
+			AbortWithError(ctx, http.StatusBadRequest, `multipart field "file" is required`)
+			return
+		}
+
+		// no "../../etc/passwd" or "C:\..." style names
+		if fh.Filename == "" || strings.ContainsAny(fh.Filename, `/\`) || strings.Contains(fh.Filename, "..") {
+			AbortWithError(ctx, http.StatusBadRequest, "invalid file name")
+			return
+		}
+
+		ext := strings.ToLower(filepath.Ext(fh.Filename))
+		if !allowedUploadExts[ext] {
+			AbortWithError(ctx, http.StatusUnsupportedMediaType, "only .png, .jpg and .pdf files are allowed")
+			return
+		}
+
+		if fh.Size > maxSize {
+			AbortWithError(ctx, http.StatusRequestEntityTooLarge, fmt.Sprintf("file is larger than %d bytes", maxSize))
+			return
+		}
+
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			AbortWithError(ctx, http.StatusInternalServerError, "could not store file")
+			return
+		}
+
+		name := uuid.NewString() + "-" + unsafeNameChars.ReplaceAllString(fh.Filename, "_")
+		dst := filepath.Join(dir, name)
+
+		src, err := fh.Open()
+		if err != nil {
+			AbortWithError(ctx, http.StatusInternalServerError, "could not read upload")
+			return
+		}
+		defer src.Close()
+
+		out, err := os.OpenFile(dst, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o644)
+		if err != nil {
+			AbortWithError(ctx, http.StatusInternalServerError, "could not store file")
+			return
+		}
+
+		// stream to disk, never more than maxSize bytes
+		_, err = io.Copy(out, io.LimitReader(src, maxSize))
+		if cerr := out.Close(); err == nil {
+			err = cerr
+		}
+		if err != nil {
+			os.Remove(dst)
+			AbortWithError(ctx, http.StatusInternalServerError, "could not store file")
+			return
+		}
+
+		// the stored name only, where dir is on this server is none of the client's business
+		ctx.JSON(http.StatusOK, gin.H{
+			"name":   name,
+			"size":   fh.Size,
+			"status": http.StatusOK,
+		})
+	}
+}
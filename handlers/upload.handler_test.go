@@ -0,0 +1,52 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+// the response names the stored file, it doesn't leak where on the server it went
+func TestUploadResponse(t *testing.T) {
+	dir := t.TempDir()
+	r := gin.New()
+	r.POST("/admin/upload", UploadHandler(dir, 1<<20))
+
+	var body bytes.Buffer
+	mw := multipart.NewWriter(&body)
+	part, _ := mw.CreateFormFile("file", "report 1.pdf")
+	part.Write([]byte("%PDF-1.7"))
+	mw.Close()
+	req := httptest.NewRequest(http.MethodPost, "/admin/upload", &body)
+	req.Header.Set("Content-Type", mw.FormDataContentType())
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200 (body %s)", w.Code, w.Body)
+	}
+	if strings.Contains(w.Body.String(), dir) {
+		t.Errorf("body %s contains the upload dir %s", w.Body, dir)
+	}
+	var resp struct {
+		Name string `json:"name"`
+		Size int64  `json:"size"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasSuffix(resp.Name, "-report_1.pdf") || strings.ContainsAny(resp.Name, `/\`) {
+		t.Errorf("name = %q, want <uuid>-report_1.pdf", resp.Name)
+	}
+	if got, err := os.ReadFile(filepath.Join(dir, resp.Name)); err != nil || string(got) != "%PDF-1.7" {
+		t.Errorf("stored file %q: %q, %v", resp.Name, got, err)
+	}
+}
@@ -0,0 +1,171 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"maps"
+	"net/http"
+	"net/url"
+	"slices"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/skyy/gin-gonic/store"
+)
+
+//💡 CRUD handlers for /admin/users, backed by store.UserStore
+type UserHandler struct {
+	Store *store.UserStore
+}
+
+func NewUserHandler(s *store.UserStore) *UserHandler {
+	return &UserHandler{Store: s}
+}
+
+// fields GET /admin/users can be sorted by, the first one is the default
+var userSortFields = []string{"id", "name", "age"}
+
+type createUserRequest struct {
+	ID string `json:"id"` // optional, generated when empty
+	UserPayload
+}
+
+// POST /admin/users
+func (h *UserHandler) Create(ctx *gin.Context) {
+	var req createUserRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		AbortWithError(ctx, http.StatusBadRequest, "invalid user", PrettyBindError(err))
+		return
+	}
+	if req.ID == "" {
+		req.ID = uuid.NewString()
+	}
+
+	u, err := h.Store.Create(store.User{ID: req.ID, Name: req.Name, Age: *req.Age})
+	if errors.Is(err, store.ErrDuplicate) {
+		AbortWithError(ctx, http.StatusConflict, T(ctx, "user %s already exists", req.ID))
+		return
+	}
+
+	// e.g. POST /api/v1/admin/users -> Location: /api/v1/admin/users/<id>
+	Created(ctx, RequestedPath(ctx)+"/"+url.PathEscape(u.ID), u)
+}
+
+// GET /admin/users?page=&per_page=&sort=&order=
+func (h *UserHandler) List(ctx *gin.Context) {
+	limit, offset, err := Paginate(ctx)
+	if err != nil {
+		AbortWithError(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	field, dir, err := ParseSort(ctx, userSortFields)
+	if err != nil {
+		AbortWithError(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	users, total := h.Store.ListSorted(field, dir == "desc", limit, offset)
+	ctx.JSON(http.StatusOK, NewPageEnvelope(users, total, limit, offset))
+}
+
+// GET /admin/users/:id
+func (h *UserHandler) Get(ctx *gin.Context) {
+	u, err := h.Store.Get(ctx.Param("id"))
+	if err != nil {
+		AbortWithError(ctx, http.StatusNotFound, "user not found")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, u)
+}
+
+// PUT /admin/users/:id
+func (h *UserHandler) Update(ctx *gin.Context) {
+	var payload UserPayload
+	if err := ctx.ShouldBindJSON(&payload); err != nil {
+		AbortWithError(ctx, http.StatusBadRequest, "invalid user", PrettyBindError(err))
+		return
+	}
+
+	u, err := h.Store.Update(ctx.Param("id"), store.User{Name: payload.Name, Age: *payload.Age})
+	if err != nil {
+		AbortWithError(ctx, http.StatusNotFound, "user not found")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, u)
+}
+
+// PATCH /admin/users/:id  {"age": 31}
+// Only the fields present in the body change, unlike PUT which replaces the whole user.
+// No field can be cleared, so null is a 400 (it would otherwise unmarshal to age 0).
+func (h *UserHandler) Patch(ctx *gin.Context) {
+	var fields map[string]json.RawMessage
+	if err := BindPartial(ctx, &fields); err != nil {
+		AbortWithError(ctx, http.StatusBadRequest, "invalid user", PrettyBindError(err))
+		return
+	}
+	var nulls []FieldError
+	for _, key := range slices.Sorted(maps.Keys(fields)) {
+		if bytes.Equal(bytes.TrimSpace(fields[key]), []byte("null")) {
+			nulls = append(nulls, FieldError{Field: key, Message: key + " can't be null"})
+		}
+	}
+	if len(nulls) > 0 {
+		AbortWithError(ctx, http.StatusBadRequest, "invalid user", nulls)
+		return
+	}
+
+	var problems []FieldError
+	u, err := h.Store.Patch(ctx.Param("id"), func(u *store.User) error {
+		for _, key := range slices.Sorted(maps.Keys(fields)) { // stable order for the error list
+			raw := fields[key]
+			switch key {
+			case "name":
+				var name string
+				if err := json.Unmarshal(raw, &name); err != nil || name == "" {
+					problems = append(problems, FieldError{Field: key, Message: "name must be a non-empty string"})
+					continue
+				}
+				u.Name = name
+			case "age":
+				var age int
+				if err := json.Unmarshal(raw, &age); err != nil || age < 0 || age > maxAge {
+					problems = append(problems, FieldError{Field: key, Message: "age must be a whole number between 0 and 150"})
+					continue
+				}
+				u.Age = age
+			default:
+				problems = append(problems, FieldError{Field: key, Message: key + " can't be changed"})
+			}
+		}
+		if len(problems) > 0 {
+			return errInvalidPatch
+		}
+		return nil
+	})
+	switch {
+	case errors.Is(err, store.ErrNotFound):
+		AbortWithError(ctx, http.StatusNotFound, "user not found")
+		return
+	case errors.Is(err, errInvalidPatch):
+		AbortWithError(ctx, http.StatusUnprocessableEntity, "invalid user", problems)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, u)
+}
+
+var errInvalidPatch = errors.New("invalid patch")
+
+// DELETE /admin/users/:id
+func (h *UserHandler) Delete(ctx *gin.Context) {
+	if err := h.Store.Delete(ctx.Param("id")); err != nil {
+		AbortWithError(ctx, http.StatusNotFound, "user not found")
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
@@ -0,0 +1,97 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/skyy/gin-gonic/middlewares"
+	"github.com/skyy/gin-gonic/store"
+)
+
+func TestPatchUser(t *testing.T) {
+	tests := []struct {
+		name     string
+		body     string
+		want     int
+		wantUser store.User // what's stored afterwards
+	}{
+		{"age changes", `{"age":31}`, http.StatusOK, store.User{ID: "u1", Name: "Skyy", Age: 31}},
+		{"age 0 is allowed", `{"age":0}`, http.StatusOK, store.User{ID: "u1", Name: "Skyy", Age: 0}},
+		{"null age is rejected", `{"age":null}`, http.StatusBadRequest, store.User{ID: "u1", Name: "Skyy", Age: 30}},
+		{"null with spaces is rejected", `{"age": null }`, http.StatusBadRequest, store.User{ID: "u1", Name: "Skyy", Age: 30}},
+		{"null name is rejected", `{"name":null,"age":31}`, http.StatusBadRequest, store.User{ID: "u1", Name: "Skyy", Age: 30}},
+		{"out of range age", `{"age":151}`, http.StatusUnprocessableEntity, store.User{ID: "u1", Name: "Skyy", Age: 30}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := store.NewUserStore()
+			if _, err := s.Create(store.User{ID: "u1", Name: "Skyy", Age: 30}); err != nil {
+				t.Fatal(err)
+			}
+			r := gin.New()
+			r.PATCH("/admin/users/:id", NewUserHandler(s).Patch)
+
+			req := httptest.NewRequest(http.MethodPatch, "/admin/users/u1", strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			if w.Code != tt.want {
+				t.Errorf("status = %d, want %d (body %s)", w.Code, tt.want, w.Body)
+			}
+			if w.Code == http.StatusBadRequest {
+				var e APIError
+				if err := json.Unmarshal(w.Body.Bytes(), &e); err != nil || e.Code != http.StatusBadRequest {
+					t.Errorf("error body %s, want an APIError", w.Body)
+				}
+			}
+			got, err := s.Get("u1")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.wantUser {
+				t.Errorf("stored user %+v, want %+v", got, tt.wantUser)
+			}
+		})
+	}
+}
+
+// Location is built from the path the client sent, so it keeps a PATH_PREFIX StripPrefix removed
+func TestCreateUserLocation(t *testing.T) {
+	tests := []struct {
+		name   string
+		prefix string
+		target string
+		want   string
+	}{
+		{"no prefix", "", "/admin/users", "/admin/users/u%2F1"},
+		{"PATH_PREFIX", "/api/v1", "/api/v1/admin/users", "/api/v1/admin/users/u%2F1"},
+		{"PATH_PREFIX with a query", "/api/v1", "/api/v1/admin/users?dry=0", "/api/v1/admin/users/u%2F1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := gin.New()
+			r.POST("/admin/users", NewUserHandler(store.NewUserStore()).Create)
+			var handler http.Handler = r
+			if tt.prefix != "" {
+				handler = middlewares.StripPrefix(tt.prefix, r)
+			}
+
+			req := httptest.NewRequest(http.MethodPost, tt.target, strings.NewReader(`{"id":"u/1","name":"Skyy","age":30}`))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, req)
+
+			if w.Code != http.StatusCreated {
+				t.Fatalf("status = %d, want 201 (body %s)", w.Code, w.Body)
+			}
+			if got := w.Header().Get("Location"); got != tt.want {
+				t.Errorf("Location = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
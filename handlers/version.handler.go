@@ -0,0 +1,30 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+//💡 Build info, stamped at build time:
+//
+//	go build -ldflags "-X github.com/skyy/gin-gonic/handlers.Version=v1.2.3 \
+//	  -X github.com/skyy/gin-gonic/handlers.Commit=$(git rev-parse --short HEAD) \
+//	  -X github.com/skyy/gin-gonic/handlers.BuildTime=$(date -u +%Y-%m-%dT%H:%M:%SZ)"
+var Version, Commit, BuildTime string
+
+// GET /version
+func VersionHandler(ctx *gin.Context) {
+	ctx.JSON(http.StatusOK, gin.H{
+		"version":    orUnknown(Version),
+		"commit":     orUnknown(Commit),
+		"build_time": orUnknown(BuildTime),
+	})
+}
+
+func orUnknown(s string) string {
+	if s == "" {
+		return "unknown"
+	}
+	return s
+}
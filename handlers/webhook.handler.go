@@ -0,0 +1,29 @@
+package handlers
+
+import (
+	"io"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+)
+
+//💡 Webhook receiver, the signature is checked by middlewares.VerifyWebhook before we get here
+// POST /webhooks
+func WebhookHandler(ctx *gin.Context) {
+	body, err := io.ReadAll(ctx.Request.Body)
+	if err != nil {
+		AbortWithError(ctx, http.StatusBadRequest, "failed to read request body")
+		return
+	}
+
+	logrus.WithFields(logrus.Fields{
+		"event": ctx.GetHeader("X-Event"),
+		"bytes": len(body),
+	}).Infoln("webhook received")
+
+	ctx.JSON(http.StatusAccepted, gin.H{
+		"received": len(body),
+		"status":   http.StatusAccepted,
+	})
+}
@@ -0,0 +1,92 @@
+// Package i18n translates user-facing (error) messages. Keys are the English
+// messages themselves, so an untranslated key still reads fine.
+package i18n
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Default is used when nothing in Accept-Language is supported
+const Default = "en"
+
+// Translate returns key in lang (falling back to English, then to key itself),
+// formatted with args like fmt.Sprintf when any are given.
+// lang may be a full tag such as "es-MX", the base language is tried too.
+func Translate(lang, key string, args ...any) string {
+	msg := key
+	if t, ok := lookup(lang, key); ok {
+		msg = t
+	}
+	if len(args) > 0 {
+		return fmt.Sprintf(msg, args...)
+	}
+	return msg
+}
+
+func lookup(lang, key string) (string, bool) {
+	lang = strings.ToLower(lang)
+	for _, l := range []string{lang, baseLang(lang)} {
+		if t, ok := catalogs[l][key]; ok {
+			return t, true
+		}
+	}
+	return "", false
+}
+
+func baseLang(tag string) string {
+	base, _, _ := strings.Cut(tag, "-")
+	return base
+}
+
+// Supported reports whether there is a catalog for lang (or its base language)
+func Supported(lang string) bool {
+	lang = strings.ToLower(lang)
+	_, ok := catalogs[lang]
+	if !ok {
+		_, ok = catalogs[baseLang(lang)]
+	}
+	return ok || lang == Default || baseLang(lang) == Default
+}
+
+// Negotiate picks the best supported language from an Accept-Language header,
+// e.g. "es-MX,es;q=0.9,en;q=0.8" -> "es-mx" (translated via "es"). Defaults to English.
+func Negotiate(acceptLanguage string) string {
+	type candidate struct {
+		tag string
+		q   float64
+	}
+	var candidates []candidate
+	for _, part := range strings.Split(acceptLanguage, ",") {
+		tag, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		tag = strings.ToLower(strings.TrimSpace(tag))
+		if tag == "" {
+			continue
+		}
+		q := 1.0
+		if v, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			parsed, err := strconv.ParseFloat(v, 64)
+			if err != nil {
+				continue
+			}
+			q = parsed
+		}
+		if q > 0 {
+			candidates = append(candidates, candidate{tag, q})
+		}
+	}
+	// highest q first, header order breaks ties
+	sort.SliceStable(candidates, func(i, j int) bool { return candidates[i].q > candidates[j].q })
+
+	for _, c := range candidates {
+		if c.tag == "*" {
+			return Default
+		}
+		if Supported(c.tag) {
+			return c.tag
+		}
+	}
+	return Default
+}
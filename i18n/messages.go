@@ -0,0 +1,36 @@
+package i18n
+
+// catalogs maps a language to its translations, keyed by the English message.
+// English needs no entries.
+var catalogs = map[string]map[string]string{
+	"es": {
+		// middlewares.Authenticate
+		"Token Not Present!": "¡Token no presente!",
+		"Invalid Token!":     "¡Token inválido!",
+
+		// handlers
+		"request body too large":      "el cuerpo de la solicitud es demasiado grande",
+		"incomplete request body":     "cuerpo de la solicitud incompleto",
+		"failed to read request body": "no se pudo leer el cuerpo de la solicitud",
+		"route %s not found":          "ruta %s no encontrada",
+		"method %s not allowed":       "método %s no permitido",
+		"invalid user":                "usuario inválido",
+		"user %s already exists":      "el usuario %s ya existe",
+		"user not found":              "usuario no encontrado",
+		"invalid file name":           "nombre de archivo inválido",
+		"could not store file":        "no se pudo guardar el archivo",
+		"could not read upload":       "no se pudo leer el archivo subido",
+		"asset not found":             "recurso no encontrado",
+		"invalid request body":        "cuerpo de la solicitud inválido",
+		"validation failed":           "validación fallida",
+		"internal server error":       "error interno del servidor",
+		"too busy, try again later":   "demasiado ocupado, inténtalo más tarde",
+
+		// middlewares.Timeout
+		"request timed out": "la solicitud agotó el tiempo de espera",
+
+		// handlers.GetUrlDataHandler
+		"name must be 1-64 letters, digits, spaces or . _ ' -": "el nombre debe tener de 1 a 64 letras, dígitos, espacios o . _ ' -",
+		"age must be a whole number between 0 and 150":         "la edad debe ser un número entero entre 0 y 150",
+	},
+}
@@ -13,12 +13,34 @@ package main
 */
 
 import (
+	"compress/gzip"
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
 	"io"
+	"net"
 	"net/http"
+	"net/http/pprof"
 	"os"
+	"os/signal"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync/atomic"
+	"syscall"
+	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/redis/go-redis/v9"
 	"github.com/sirupsen/logrus"
+	"github.com/skyy/gin-gonic/auth/jwt"
+	"github.com/skyy/gin-gonic/config"
+	"github.com/skyy/gin-gonic/handlers"
+	"github.com/skyy/gin-gonic/middlewares"
+	"github.com/skyy/gin-gonic/routes"
+	"github.com/skyy/gin-gonic/store"
+	"github.com/skyy/gin-gonic/workerpool"
 )
 
 func main() {
@@ -47,10 +69,462 @@ func main() {
     logrus.Debugln("Debug 🟡")
     logrus.Infoln("Info 🟠")
 
+    //💡 CONFIG_FILE=config.yaml for per-environment settings, env vars still win
+    var cfg config.Config
+    if path := os.Getenv("CONFIG_FILE"); path != "" {
+        cfg, err = config.LoadFromFile(path)
+    } else {
+        cfg, err = config.Load()
+    }
+    if err != nil {
+        logrus.Fatalln("Error loading config: ", err)
+    }
+
+    //💡 Access logs -> rotating file (ACCESS_LOG_FORMAT=json for machine-parseable lines),
+    // plus the colorized FormatLogs on the console in debug mode, one line each per request
+    logFile, err := middlewares.OpenLogFile("ginLogging.log", 10)
+    if err != nil {
+        logrus.Fatalln("Error opening access log: ", err)
+    }
+    defer logFile.Close()
+    fileFormat := middlewares.FormatLogs
+    if os.Getenv("ACCESS_LOG_FORMAT") == "json" {
+        fileFormat = middlewares.FormatLogsJSON
+    }
+    logTargets := []middlewares.LogTarget{{Writer: logFile, Formatter: fileFormat, SkipPaths: middlewares.DefaultSkipPaths}}
+    if gin.Mode() == gin.DebugMode {
+        logTargets = append(logTargets, middlewares.LogTarget{Writer: os.Stdout, Formatter: middlewares.FormatLogs, SkipPaths: middlewares.DefaultSkipPaths})
+    }
+
     router := gin.New()
-    router.GET("/getData", GetDatahandler)
+    router.RedirectTrailingSlash = cfg.RedirectTrailingSlash
+    router.RedirectFixedPath = cfg.RedirectFixedPath
+    // nil trusts no proxy, so a client can't spoof its IP with X-Forwarded-For
+    if err := router.SetTrustedProxies(cfg.TrustedProxies); err != nil {
+        logrus.Fatalln("Error setting trusted proxies: ", err)
+    }
+    router.Use(middlewares.RequestID())
+    router.Use(middlewares.CaptureRoute()) // route template for the JSON logs
+    router.Use(middlewares.Tracing("github.com/skyy/gin-gonic")) // no-op until a TracerProvider is installed
+    router.Use(middlewares.ServerTiming())
+    router.Use(middlewares.MultiLogger(logTargets...)...)
+    router.Use(middlewares.PrometheusMiddleware())
+    // inside the loggers & metrics (like gin.Default), so a panicked request is still logged and counted as a 500
+    router.Use(middlewares.RecoveryJSON())
+    if cfg.ForceHTTPS {
+        // probes hit the pod directly over HTTP, so they're exempt
+        router.Use(middlewares.HTTPSRedirect(cfg.CanonicalHost, cfg.ProxyProtoHeader, "/healthz", "/readyz", "/metrics"))
+    }
+    router.Use(middlewares.ConcurrencyLimit(cfg.MaxInFlight, 100*time.Millisecond)) // global cap (after logs/metrics so 503s show up), short queue first
+
+    //💡 Maintenance (read-only) mode, toggled with SIGUSR1 or PUT /admin/maintenance
+    var maintenance atomic.Bool
+    router.Use(middlewares.MaintenanceMode(&maintenance, 5*time.Minute, "/login", "/admin/maintenance"))
+    router.Use(middlewares.MaxHeaders(cfg.MaxHeaders))
+    router.Use(middlewares.MaxBodyBytes(1<<20, "/admin/upload")) // 1 MB, uploads get their own cap
+    router.Use(middlewares.SecurityHeaders(middlewares.SecurityOptions{ProxyProtoHeader: cfg.ProxyProtoHeader}))
+    router.Use(middlewares.Gzip(gzip.DefaultCompression))
+    if os.Getenv("LOG_BODIES") == "true" {
+        // debugging only: JSON access log to stdout with (capped) request/response bodies
+        router.Use(middlewares.Logger(middlewares.FormatLogsJSON, middlewares.DefaultSkipPaths...), middlewares.BodyLogger(4<<10))
+    }
+
+    if os.Getenv("DEBUG_ECHO") == "true" {
+        // debugging client integrations: X-Debug-Echo: true returns the request instead of handling it
+        if gin.Mode() == gin.ReleaseMode {
+            logrus.Warnln("DEBUG_ECHO ignored in release mode")
+        } else {
+            router.Use(middlewares.Echo())
+        }
+    }
+
+    //💡 CORS for the front-end, e.g. CORS_ORIGINS="http://localhost:3000,https://app.example.com"
+    if origins := os.Getenv("CORS_ORIGINS"); origins != "" {
+        router.Use(middlewares.CORS(middlewares.CORSOptions{
+            AllowOrigins:     strings.Split(origins, ","),
+            AllowMethods:     []string{"GET", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"},
+            AllowHeaders:     []string{"Authorization", "Content-Type", "Token"},
+            AllowCredentials: true,
+        }))
+    }
+    router.Use(handlers.ErrorCollector()) // last global mw: renders ctx.Error()s through gzip & co.
+
+    // Cache replays the tagged body, so ETag sits inside it
+    cache, purgeCache := middlewares.Cache(30 * time.Second)
+    router.GET("/", cache, middlewares.ETag(), handlers.RootHandler) // Respond sniffs Accept itself here
+    router.POST("/", middlewares.RequireContentType("application/json"), handlers.PostHandler)
+
+    //💡 Static web UI (public, no directory listings)
+    router.StaticFS("/static", handlers.NoDirListing(http.Dir(cfg.PublicDir)))
+    router.GET("/favicon.ico", handlers.Favicon(filepath.Join(cfg.PublicDir, "favicon.ico")))
+    // built front-end bundles (hashed names, long cache, precompressed .br/.gz)
+    assets := handlers.Assets(filepath.Join(cfg.PublicDir, "assets"))
+    router.GET("/assets/*filepath", assets)
+    router.HEAD("/assets/*filepath", assets)
+
+    //💡 Probes for the load balancer, outside any auth group
+    router.GET("/healthz", handlers.HealthHandler)
+    router.GET("/readyz", handlers.ReadyHandler)
+    router.GET("/metrics", middlewares.MetricsHandler())
+    router.GET("/version", handlers.VersionHandler)
+
+    //💡 Expected token comes from the env, never from source
+    authToken := os.Getenv("AUTH_TOKEN")
+    if authToken == "" {
+        logrus.Fatalln("AUTH_TOKEN is not set")
+    }
+
+    router.GET("/getData", middlewares.Authenticate(authToken), GetDatahandler)
+
+    //💡 Webhooks, X-Signature = HMAC-SHA256 of "<X-Timestamp>.<body>" with WEBHOOK_SECRET (route is off without a secret).
+    // Requests older than 5 minutes are refused, so a captured one can't be replayed later.
+    if secret := os.Getenv("WEBHOOK_SECRET"); secret != "" {
+        router.POST("/webhooks",
+            middlewares.RequireFreshTimestamp("X-Timestamp", 5*time.Minute),
+            middlewares.VerifyWebhook([]byte(secret), "X-Signature", "X-Timestamp"),
+            handlers.WebhookHandler)
+    }
+
+    //💡 Accounts 🛡️ (bcrypt-hashed, from a file, see middlewares.LoadAccounts)
+    accountsFile := os.Getenv("ACCOUNTS_FILE")
+    if accountsFile == "" {
+        accountsFile = "accounts.json"
+    }
+    configFile := os.Getenv("CONFIG_FILE")
+    loadAccounts := func() (gin.Accounts, error) {
+        if configFile != "" {
+            // accounts in the YAML config win over ACCOUNTS_FILE
+            fileCfg, err := config.LoadFromFile(configFile)
+            if err != nil {
+                return nil, err
+            }
+            if len(fileCfg.Accounts) > 0 {
+                return fileCfg.Accounts, nil
+            }
+        }
+        return middlewares.LoadAccounts(accountsFile)
+    }
+    accounts := middlewares.NewAccountStore(cfg.Accounts)
+    if len(cfg.Accounts) == 0 {
+        loaded, err := loadAccounts()
+        if err != nil {
+            logrus.Fatalln("Error loading accounts: ", err)
+        }
+        accounts.Set(loaded)
+    }
+
+    //💡 JWT: POST /login trades the account credentials for a token,
+    // users listed in ADMIN_USERS (comma separated) get the "admin" role
+    jwtSecret := []byte(os.Getenv("JWT_SECRET"))
+    if len(jwtSecret) == 0 {
+        logrus.Fatalln("JWT_SECRET is not set")
+    }
+    roles := map[string][]string{}
+    for _, user := range strings.Split(os.Getenv("ADMIN_USERS"), ",") {
+        if user = strings.TrimSpace(user); user != "" {
+            roles[user] = append(roles[user], "admin")
+        }
+    }
+    jsonOnly := middlewares.RequireContentType("application/json")
+    loginLimit, stopLoginLimit := middlewares.RateLimit(1, 5)
+    defer stopLoginLimit()
+    router.POST("/login", loginLimit, jsonOnly, jwt.LoginHandlerFunc(accounts.Get, roles, jwtSecret, time.Hour))
+
+    //💡 Audit trail of admin changes (who/what/when), append-only JSON lines in AUDIT_LOG_FILE
+    auditFile := os.Getenv("AUDIT_LOG_FILE")
+    if auditFile == "" {
+        auditFile = "audit.log"
+    }
+    auditSink, err := middlewares.NewFileAuditSink(auditFile)
+    if err != nil {
+        logrus.Fatalln("Error opening audit log: ", err)
+    }
+    defer auditSink.Close()
+
+    //💡 10 failed logins (401/403) within 5 minutes ban the client IP for 15 minutes
+    throttle, stopThrottle := middlewares.ThrottleOnFailure(10, 5*time.Minute, 15*time.Minute)
+    defer stopThrottle()
+
+    //💡 Admin rate limit: per replica by default, shared by all replicas when REDIS_ADDR="host:6379" is set
+    adminLimit, stopAdminLimit := middlewares.RateLimit(5, 10)
+    defer stopAdminLimit()
+    if addr := os.Getenv("REDIS_ADDR"); addr != "" {
+        rdb := redis.NewClient(&redis.Options{Addr: addr})
+        defer rdb.Close()
+        adminLimit = middlewares.RedisRateLimit(rdb, 10, 2*time.Second) // same budget as RateLimit(5, 10), roughly
+    }
+
+    //💡 Grouping routes 🛜 (admin: rate limited + JWT + admin role, downstream calls bounded by the write timeout)
+    adminStack := newAdminStack(throttle, adminLimit, jwtSecret, auditSink, cfg.WriteTimeout)
+
+    //💡 Client API keys, API_KEYS="principal:key,principal:key2" (several keys per principal for rotation)
+    apiKeys := middlewares.NewMemoryKeyStore()
+    for _, pair := range strings.Split(os.Getenv("API_KEYS"), ",") {
+        if principal, key, ok := strings.Cut(strings.TrimSpace(pair), ":"); ok && principal != "" && key != "" {
+            apiKeys.Add(principal, key)
+        }
+    }
+    clientStack, streamStack := newClientStacks(apiKeys)
+
+    //💡 Multi-tenancy: with TENANT_HEADER="X-Tenant-ID" every admin & client request must name its tenant (400 otherwise)
+    if header := os.Getenv("TENANT_HEADER"); header != "" {
+        tenant := middlewares.TenantResolver(middlewares.TenantFromHeader(header))
+        adminStack = append(adminStack, tenant)
+        clientStack = append(clientStack, tenant)
+        streamStack = append(streamStack, tenant)
+    }
 
-    router.Run(":8081")
+    users := handlers.NewUserHandler(store.NewUserStore())
+    userSchema := middlewares.ValidateSchema("schemas/user.schema.json")
+    jobs := workerpool.New(4, 100) // background work (reports ...), drained on shutdown
+    idempotent := middlewares.Idempotency(middlewares.NewMemoryIdempotencyStore(24 * time.Hour)) // retried creates replay the first response
+    const maxUpload = 10 << 20 // 10 MB
+    router.MaxMultipartMemory = 1 << 20 // bigger uploads spool to a temp file, not RAM
+
+    //💡 Route table 📋 admin & client routes (Path is relative to Group), also the source of /openapi.json
+    table := slices.Concat(
+        routes.InGroup("/admin", routes.SecurityBearer, []routes.Route{
+            {Method: http.MethodGet, Path: "/get-body-data", Handler: handlers.GetBodyDataHandler},
+            {Method: http.MethodPost, Path: "/post-body-data", Handler: handlers.PostBodyDataHandler, Middlewares: []gin.HandlerFunc{jsonOnly}},
+            {Method: http.MethodGet, Path: "/get-QryStr", Handler: handlers.GetQryDataHandler},
+            {Method: http.MethodGet, Path: "/get-QryArray", Handler: handlers.GetQryArrayHandler},
+            {Method: http.MethodGet, Path: "/get-UrlParams/:name/:age", Handler: handlers.GetUrlDataHandler},
+
+            {Method: http.MethodGet, Path: "/users", Handler: users.List},
+            {Method: http.MethodPost, Path: "/users", Handler: users.Create, Middlewares: []gin.HandlerFunc{jsonOnly, idempotent, userSchema}},
+            {Method: http.MethodGet, Path: "/users/:id", Handler: users.Get},
+            {Method: http.MethodPut, Path: "/users/:id", Handler: users.Update, Middlewares: []gin.HandlerFunc{jsonOnly, userSchema}},
+            {Method: http.MethodPatch, Path: "/users/:id", Handler: users.Patch, Middlewares: []gin.HandlerFunc{jsonOnly}},
+            {Method: http.MethodDelete, Path: "/users/:id", Handler: users.Delete},
+
+            {Method: http.MethodGet, Path: "/maintenance", Handler: handlers.MaintenanceHandler(&maintenance)},
+            {Method: http.MethodPut, Path: "/maintenance", Handler: handlers.MaintenanceHandler(&maintenance), Middlewares: []gin.HandlerFunc{jsonOnly}},
+
+            {Method: http.MethodPost, Path: "/reports", Handler: handlers.ReportHandler(jobs),
+                Middlewares: []gin.HandlerFunc{middlewares.CircuitBreaker("reports", middlewares.BreakerOptions{FailureThreshold: 5, Cooldown: 30 * time.Second, IsFailure: handlers.ReportFailed})}},
+
+            {Method: http.MethodDelete, Path: "/cache", Handler: func(ctx *gin.Context) {
+                purgeCache()
+                ctx.Status(http.StatusNoContent)
+            }},
+
+            {Method: http.MethodPost, Path: "/upload", Handler: handlers.UploadHandler(cfg.UploadDir, maxUpload),
+                Middlewares: []gin.HandlerFunc{middlewares.MaxBodyBytes(maxUpload + 1<<20), middlewares.RequireContentType("multipart/form-data")}},
+        }),
+
+        // streams run for as long as the client stays, so keep these out of the Timeout group
+        routes.InGroup("", routes.SecurityAPIKey, []routes.Route{
+            {Method: http.MethodGet, Path: "/client/events", Handler: handlers.EventsHandler, Middlewares: streamStack},
+            {Method: http.MethodGet, Path: "/client/progress", Handler: handlers.ProgressHandler, Middlewares: streamStack},
+        }),
+
+        routes.InGroup("/client", routes.SecurityAPIKey, []routes.Route{
+            {Method: http.MethodGet, Path: "/get-UrlParams/:name/:age", Handler: handlers.GetUrlDataHandler},
+        }),
+    )
+    err = routes.RegisterRoutes(router, table,
+        routes.Group{Prefix: "/admin", Middlewares: adminStack},
+        routes.Group{Prefix: "/client", Middlewares: clientStack},
+    )
+    if err != nil {
+        logrus.Fatalln("Error registering routes: ", err)
+    }
+
+    spec, err := routes.GenerateOpenAPI(table)
+    if err != nil {
+        logrus.Fatalln("Error generating OpenAPI spec: ", err)
+    }
+    router.GET("/openapi.json", func(ctx *gin.Context) {
+        ctx.Data(http.StatusOK, "application/json; charset=utf-8", spec)
+    })
+
+    //💡 Ops area: its own credential set & realm (OPS_ACCOUNTS_FILE, bcrypt like ACCOUNTS_FILE)
+    opsAccounts := accounts
+    opsAccountsFile := os.Getenv("OPS_ACCOUNTS_FILE")
+    if opsAccountsFile != "" {
+        opsAccounts = middlewares.NewAccountStore(nil)
+        if err := opsAccounts.Reload(opsAccountsFile); err != nil {
+            logrus.Fatalln("Error loading ops accounts: ", err)
+        }
+    }
+    opsRoutes := router.Group("/ops", throttle, middlewares.BasicAuthStore(opsAccounts, "Ops Area"))
+    {
+        opsRoutes.GET("/whoami", func(ctx *gin.Context) {
+            ctx.JSON(http.StatusOK, gin.H{"user": ctx.GetString(gin.AuthUserKey)})
+        })
+    }
+
+    //💡 pprof for staging only (ENABLE_PPROF=true), behind basic auth
+    if os.Getenv("ENABLE_PPROF") == "true" {
+        debugRoutes := router.Group("/debug/pprof", middlewares.BasicAuthStore(accounts, "Authorization Required"))
+        {
+            debugRoutes.GET("/", gin.WrapF(pprof.Index))
+            debugRoutes.GET("/profile", gin.WrapF(pprof.Profile))
+            debugRoutes.GET("/trace", gin.WrapF(pprof.Trace))
+            debugRoutes.GET("/heap", gin.WrapH(pprof.Handler("heap")))
+            debugRoutes.GET("/goroutine", gin.WrapH(pprof.Handler("goroutine")))
+        }
+    }
+
+    //💡 SIGHUP re-reads the credential files, so accounts can be rotated without
+    // dropping connections. A broken file is logged and the old accounts stay.
+    hup := make(chan os.Signal, 1)
+    signal.Notify(hup, syscall.SIGHUP)
+    go func() {
+        for range hup {
+            loaded, err := loadAccounts()
+            if err != nil {
+                logrus.Errorf("⚠️accounts reload failed, keeping the old ones: %v", err)
+                continue
+            }
+            accounts.Set(loaded)
+            if opsAccountsFile != "" {
+                if err := opsAccounts.Reload(opsAccountsFile); err != nil {
+                    logrus.Errorf("⚠️ops accounts reload failed, keeping the old ones: %v", err)
+                    continue
+                }
+            }
+            logrus.Infof("Accounts reloaded (%d user(s)) 🟢", len(loaded))
+        }
+    }()
+
+    //💡 SIGUSR1 flips maintenance mode (kill -USR1 <pid>)
+    usr1 := make(chan os.Signal, 1)
+    signal.Notify(usr1, syscall.SIGUSR1)
+    go func() {
+        for range usr1 {
+            enabled := !maintenance.Load()
+            for !maintenance.CompareAndSwap(!enabled, enabled) { // lost a race with the admin endpoint
+                enabled = !maintenance.Load()
+            }
+            logrus.WithField("enabled", enabled).Warnln("maintenance mode switched via SIGUSR1")
+        }
+    }()
+
+    //💡 Uniform APIError bodies for unknown routes / wrong methods
+    router.HandleMethodNotAllowed = true
+    router.NoRoute(handlers.NotFoundHandler)
+    router.NoMethod(handlers.MethodNotAllowedHandler(router))
+
+    //💡 custom http-config ⚙️ (count open conns so shutdown can report what it drained)
+    var openConns atomic.Int64
+    var handler http.Handler = router
+    if cfg.PathPrefix != "" {
+        handler = middlewares.StripPrefix(cfg.PathPrefix, handler) // every route now lives under e.g. /api/v1
+    }
+    if cfg.H2C && !cfg.TLSEnabled() {
+        handler = withH2C(handler, cfg.IdleTimeout)
+    }
+    server := &http.Server{
+        Addr:              cfg.Addr(),
+        Handler:           handler,
+        ReadTimeout:       cfg.ReadTimeout,
+        WriteTimeout:      cfg.WriteTimeout,
+        ReadHeaderTimeout: cfg.ReadHeaderTimeout,
+        IdleTimeout:       cfg.IdleTimeout,
+        MaxHeaderBytes:    cfg.MaxHeaderBytes, // oversized headers get a 431 from net/http itself
+        TLSConfig: &tls.Config{
+            MinVersion: tls.VersionTLS12,
+        },
+        ConnState: func(_ net.Conn, state http.ConnState) {
+            switch state {
+            case http.StateNew:
+                openConns.Add(1)
+            case http.StateClosed, http.StateHijacked:
+                openConns.Add(-1)
+            }
+        },
+    }
+
+    //💡 Graceful shutdown on SIGINT/SIGTERM (orchestrators send SIGTERM on deploys)
+    sigs := make(chan os.Signal, 1)
+    signal.Notify(sigs, os.Interrupt, syscall.SIGTERM)
+
+    ln, err := listen(cfg.Port, cfg.AltPorts)
+    if err != nil {
+        logrus.Fatalf("⚠️failed to run server: %v", err)
+    }
+    server.Addr = ln.Addr().String()
+
+    routes := router.Routes()
+    middlewares.LogRoutes(routes)
+    middlewares.LogLifecycle("startup", gin.H{
+        "addr":                server.Addr,
+        "tls":                 cfg.TLSEnabled(),
+        "h2c":                 cfg.H2C && !cfg.TLSEnabled(),
+        "path_prefix":         cfg.PathPrefix,
+        "read_timeout":        cfg.ReadTimeout.String(),
+        "write_timeout":       cfg.WriteTimeout.String(),
+        "read_header_timeout": cfg.ReadHeaderTimeout.String(),
+        "idle_timeout":        cfg.IdleTimeout.String(),
+        "routes":              len(routes),
+        "version":             handlers.Version,
+    })
+
+    go func() {
+        var err error
+        if cfg.TLSEnabled() {
+            err = server.ServeTLS(ln, cfg.TLSCert, cfg.TLSKey)
+        } else {
+            err = server.Serve(ln)
+        }
+        if err != nil && !errors.Is(err, http.ErrServerClosed) {
+            logrus.Fatalf("⚠️failed to run server: %v", err)
+        }
+    }()
+    handlers.SetReady(true)
+
+    sig := <-sigs
+    signal.Stop(sigs) // a second Ctrl+C kills the process the default way
+    handlers.SetReady(false)
+
+    draining := openConns.Load()
+    middlewares.LogLifecycle("shutdown", gin.H{
+        "reason":      sig.String(),
+        "connections": draining,
+    })
+
+    start := time.Now()
+    shutdownCtx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+    defer cancel()
+    if err := server.Shutdown(shutdownCtx); err != nil {
+        middlewares.LogLifecycle("stopped", gin.H{
+            "latency":     time.Since(start),
+            "connections": draining,
+            "not_drained": openConns.Load(),
+            "error":       err.Error(),
+        })
+        return
+    }
+    // no request can submit anymore, let the queued jobs finish within what's left of the timeout
+    if err := jobs.Shutdown(shutdownCtx); err != nil {
+        middlewares.LogLifecycle("stopped", gin.H{
+            "latency":     time.Since(start),
+            "connections": draining,
+            "error":       "background jobs: " + err.Error(),
+        })
+        return
+    }
+    middlewares.LogLifecycle("stopped", gin.H{
+        "latency":     time.Since(start),
+        "connections": draining,
+    })
+}
+
+//💡 listen tries port, then each alt port, skipping ports that are already taken
+// (e.g. a stale `air` process still holding it)
+func listen(port string, altPorts []string) (net.Listener, error) {
+    for _, p := range append([]string{port}, altPorts...) {
+        ln, err := net.Listen("tcp", ":"+p)
+        if err == nil {
+            return ln, nil
+        }
+        if !errors.Is(err, syscall.EADDRINUSE) {
+            return nil, err
+        }
+        logrus.Warnf("⚠️port %s is already in use, is another instance still running? (find it with `lsof -i :%s`, or set PORT / ALT_PORTS)", p, p)
+    }
+    return nil, fmt.Errorf("no free port among %s %v", port, altPorts)
 }
 
 func GetDatahandler(ctx *gin.Context) {
@@ -0,0 +1,301 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/skyy/gin-gonic/auth/jwt"
+	"github.com/skyy/gin-gonic/middlewares"
+)
+
+func TestMain(m *testing.M) {
+	gin.SetMode(gin.TestMode)
+	os.Exit(m.Run())
+}
+
+var testSecret = []byte("test-secret")
+
+// traced puts a probe after every middleware of stack; *passed collects the
+// indexes of the ones that called Next, so it shows both order and where a request stopped
+func traced(stack gin.HandlersChain, passed *[]int) gin.HandlersChain {
+	var out gin.HandlersChain
+	for i, h := range stack {
+		out = append(out, h, func(ctx *gin.Context) {
+			*passed = append(*passed, i)
+			ctx.Next()
+		})
+	}
+	return out
+}
+
+type memoryAuditSink struct {
+	entries []middlewares.AuditEntry
+}
+
+func (s *memoryAuditSink) Record(entry middlewares.AuditEntry) error {
+	s.entries = append(s.entries, entry)
+	return nil
+}
+
+func bearer(t *testing.T, claims jwt.Claims) string {
+	t.Helper()
+	token, err := jwt.IssueToken(claims, testSecret, time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return "Bearer " + token
+}
+
+// a RateLimit whose eviction goroutine ends with the test
+func rateLimit(t *testing.T, rps, burst int) gin.HandlerFunc {
+	t.Helper()
+	limit, stop := middlewares.RateLimit(rps, burst)
+	t.Cleanup(stop)
+	return limit
+}
+
+// a ThrottleOnFailure (one minute window and ban) whose cleanup goroutine ends with the test
+func throttle(t *testing.T, threshold int) gin.HandlerFunc {
+	t.Helper()
+	h, stop := middlewares.ThrottleOnFailure(threshold, time.Minute, time.Minute)
+	t.Cleanup(stop)
+	return h
+}
+
+// indexes into newAdminStack
+const (
+	adminThrottle = iota
+	adminLimit
+	adminJWT
+	adminRole
+	adminAudit
+	adminDeadline
+	adminAccept
+)
+
+func TestAdminStackOrder(t *testing.T) {
+	tests := []struct {
+		name       string
+		headers    map[string]string
+		wantStatus int
+		wantPassed []int
+		wantAudit  bool
+	}{
+		{
+			name:       "no token stops at JWTAuth",
+			wantStatus: http.StatusUnauthorized,
+			wantPassed: []int{adminThrottle, adminLimit},
+		},
+		{
+			name:       "non-admin stops at RequireRole, before the audit log",
+			headers:    map[string]string{"Authorization": bearer(t, jwt.Claims{Subject: "bob"})},
+			wantStatus: http.StatusForbidden,
+			wantPassed: []int{adminThrottle, adminLimit, adminJWT},
+		},
+		{
+			name:       "unsupported Accept is a 406 after auth, and still audited",
+			headers:    map[string]string{"Authorization": bearer(t, jwt.Claims{Subject: "alice", Role: "admin"}), "Accept": "image/png"},
+			wantStatus: http.StatusNotAcceptable,
+			wantPassed: []int{adminThrottle, adminLimit, adminJWT, adminRole, adminAudit, adminDeadline},
+			wantAudit:  true,
+		},
+		{
+			name:       "admin reaches the handler",
+			headers:    map[string]string{"Authorization": bearer(t, jwt.Claims{Subject: "alice", Role: "admin"})},
+			wantStatus: http.StatusCreated,
+			wantPassed: []int{adminThrottle, adminLimit, adminJWT, adminRole, adminAudit, adminDeadline, adminAccept},
+			wantAudit:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sink := &memoryAuditSink{}
+			stack := newAdminStack(throttle(t, 10), rateLimit(t, 5, 10), testSecret, sink, time.Second)
+
+			var passed []int
+			handlerRan := false
+			r := gin.New()
+			r.POST("/admin/users", append(traced(stack, &passed), func(ctx *gin.Context) {
+				handlerRan = true
+				ctx.Status(http.StatusCreated)
+			})...)
+
+			req := httptest.NewRequest(http.MethodPost, "/admin/users", nil)
+			for k, v := range tt.headers {
+				req.Header.Set(k, v)
+			}
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			if w.Code != tt.wantStatus {
+				t.Errorf("status = %d, want %d (body %s)", w.Code, tt.wantStatus, w.Body)
+			}
+			if !slices.Equal(passed, tt.wantPassed) {
+				t.Errorf("passed middlewares %v, want %v", passed, tt.wantPassed)
+			}
+			if want := len(tt.wantPassed) == len(stack); handlerRan != want {
+				t.Errorf("handler ran = %v, want %v", handlerRan, want)
+			}
+			if got := len(sink.entries) == 1; got != tt.wantAudit {
+				t.Errorf("audited = %v (%d entries), want %v", got, len(sink.entries), tt.wantAudit)
+			}
+			if tt.wantAudit && len(sink.entries) == 1 && sink.entries[0].Principal != "alice" {
+				t.Errorf("audit principal = %q, want alice", sink.entries[0].Principal)
+			}
+		})
+	}
+}
+
+// the rate limit sits in front of auth, so an anonymous flood never reaches JWT parsing
+func TestAdminStackRateLimitBeforeAuth(t *testing.T) {
+	stack := newAdminStack(throttle(t, 10), rateLimit(t, 1, 1), testSecret, &memoryAuditSink{}, time.Second)
+	var passed []int
+	r := gin.New()
+	r.GET("/admin/users", traced(stack, &passed)...)
+
+	var codes []int
+	for range 2 {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/admin/users", nil))
+		codes = append(codes, w.Code)
+	}
+
+	if want := []int{http.StatusUnauthorized, http.StatusTooManyRequests}; !slices.Equal(codes, want) {
+		t.Errorf("statuses %v, want %v", codes, want)
+	}
+	// the second request stopped at the limiter: only the throttle of it passed
+	if want := []int{adminThrottle, adminLimit, adminThrottle}; !slices.Equal(passed, want) {
+		t.Errorf("passed middlewares %v, want %v", passed, want)
+	}
+}
+
+// the failure throttle is outermost: once banned, not even the rate limiter sees the client
+func TestAdminStackThrottleOutermost(t *testing.T) {
+	stack := newAdminStack(throttle(t, 2), rateLimit(t, 100, 100), testSecret, &memoryAuditSink{}, time.Second)
+	var passed []int
+	r := gin.New()
+	r.GET("/admin/users", traced(stack, &passed)...)
+
+	var codes []int
+	for range 3 {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/admin/users", nil))
+		codes = append(codes, w.Code)
+	}
+
+	if want := []int{http.StatusUnauthorized, http.StatusUnauthorized, http.StatusTooManyRequests}; !slices.Equal(codes, want) {
+		t.Errorf("statuses %v, want %v", codes, want)
+	}
+	if want := []int{adminThrottle, adminLimit, adminThrottle, adminLimit}; !slices.Equal(passed, want) {
+		t.Errorf("passed middlewares %v, want %v", passed, want)
+	}
+}
+
+func TestClientStackOrder(t *testing.T) {
+	keys := middlewares.NewMemoryKeyStore()
+	keys.Add("svc", "key-1")
+
+	tests := []struct {
+		name       string
+		headers    map[string]string
+		wantStatus int
+		wantPassed []int
+	}{
+		{"no key stops at APIKeyAuth", map[string]string{"Accept": "image/png"}, http.StatusUnauthorized, nil},
+		{"wrong key stops at APIKeyAuth", map[string]string{"X-API-Key": "nope"}, http.StatusUnauthorized, nil},
+		{"unsupported Accept stops at AcceptFilter", map[string]string{"X-API-Key": "key-1", "Accept": "image/png"}, http.StatusNotAcceptable, []int{0}},
+		{"valid key reaches the handler", map[string]string{"X-API-Key": "key-1"}, http.StatusOK, []int{0, 1, 2}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, _ := newClientStacks(keys)
+			var passed []int
+			r := gin.New()
+			r.GET("/client/ping", append(traced(client, &passed), func(ctx *gin.Context) {
+				ctx.Status(http.StatusOK)
+			})...)
+
+			req := httptest.NewRequest(http.MethodGet, "/client/ping", nil)
+			for k, v := range tt.headers {
+				req.Header.Set(k, v)
+			}
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			if w.Code != tt.wantStatus {
+				t.Errorf("status = %d, want %d (body %s)", w.Code, tt.wantStatus, w.Body)
+			}
+			if !slices.Equal(passed, tt.wantPassed) {
+				t.Errorf("passed middlewares %v, want %v", passed, tt.wantPassed)
+			}
+		})
+	}
+}
+
+func TestStreamStackHasNoTimeout(t *testing.T) {
+	keys := middlewares.NewMemoryKeyStore()
+	keys.Add("svc", "key-1")
+	_, stream := newClientStacks(keys)
+
+	var hasDeadline bool
+	r := gin.New()
+	r.GET("/client/events", append(stream, func(ctx *gin.Context) {
+		_, hasDeadline = ctx.Request.Context().Deadline()
+		ctx.Status(http.StatusOK)
+	})...)
+
+	req := httptest.NewRequest(http.MethodGet, "/client/events", nil)
+	req.Header.Set("X-API-Key", "key-1")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200", w.Code)
+	}
+	if hasDeadline {
+		t.Error("stream handler got a deadline, streams must not run under Timeout")
+	}
+}
+
+// Authenticate's AbortWithStatusJSON must keep /getData's handler from running
+func TestAuthenticateShortCircuits(t *testing.T) {
+	tests := []struct {
+		name        string
+		token       string
+		wantStatus  int
+		wantHandler bool
+	}{
+		{"missing token", "", http.StatusUnauthorized, false},
+		{"wrong token", "nope", http.StatusForbidden, false},
+		{"valid token", "secret", http.StatusOK, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handlerRan := false
+			r := gin.New()
+			r.GET("/getData", middlewares.Authenticate("secret"), func(ctx *gin.Context) {
+				handlerRan = true
+				ctx.Next()
+			}, GetDatahandler)
+
+			req := httptest.NewRequest(http.MethodGet, "/getData", nil)
+			if tt.token != "" {
+				req.Header.Set("Token", tt.token)
+			}
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			if w.Code != tt.wantStatus {
+				t.Errorf("status = %d, want %d (body %s)", w.Code, tt.wantStatus, w.Body)
+			}
+			if handlerRan != tt.wantHandler {
+				t.Errorf("handler ran = %v, want %v", handlerRan, tt.wantHandler)
+			}
+		})
+	}
+}
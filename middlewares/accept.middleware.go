@@ -0,0 +1,106 @@
+package middlewares
+
+import (
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/skyy/gin-gonic/ctxkeys"
+)
+
+type mediaRange struct {
+	typ, subtype string
+	q            float64
+}
+
+// how specific r is for matching: type/subtype beats type/* beats */*, -1 = no match
+func (r mediaRange) match(typ, subtype string) int {
+	switch {
+	case r.typ == typ && r.subtype == subtype:
+		return 2
+	case r.typ == typ && r.subtype == "*":
+		return 1
+	case r.typ == "*" && r.subtype == "*":
+		return 0
+	}
+	return -1
+}
+
+// parseAccept keeps the well-formed ranges of header, malformed parts
+// (no subtype, q outside 0..1 ...) are dropped rather than failing the whole header
+func parseAccept(header string) []mediaRange {
+	var ranges []mediaRange
+	for _, part := range strings.Split(header, ",") {
+		mt, params, err := mime.ParseMediaType(strings.TrimSpace(part))
+		if err != nil {
+			continue
+		}
+		typ, subtype, ok := strings.Cut(mt, "/")
+		if !ok || typ == "" || subtype == "" || (typ == "*" && subtype != "*") {
+			continue
+		}
+		q, ok := parseQ(params)
+		if !ok {
+			continue
+		}
+		ranges = append(ranges, mediaRange{typ, subtype, q})
+	}
+	return ranges
+}
+
+// parseQ reads the q parameter of an Accept* header part, 1 when absent,
+// ok = false when it isn't a number in 0..1
+func parseQ(params map[string]string) (q float64, ok bool) {
+	v, found := params["q"]
+	if !found {
+		return 1, true
+	}
+	q, err := strconv.ParseFloat(v, 64)
+	if err != nil || q < 0 || q > 1 {
+		return 0, false
+	}
+	return q, true
+}
+
+//💡 AcceptFilter picks the response type from the Accept header (q values and wildcards,
+// the most specific matching range decides a type's q) among supported, in our order of
+// preference, and keeps it for handlers.Respond (see ctxkeys.GetNegotiatedType). Without a
+// usable Accept header the first supported type is used; when none of them is acceptable: 406.
+func AcceptFilter(supported ...string) gin.HandlerFunc {
+	if len(supported) == 0 {
+		panic("⚠️AcceptFilter: no supported types")
+	}
+
+	return func(ctx *gin.Context) {
+		ranges := parseAccept(ctx.GetHeader("Accept"))
+		if len(ranges) == 0 {
+			ctxkeys.SetNegotiatedType(ctx, supported[0])
+			ctx.Next()
+			return
+		}
+
+		best, bestQ := "", 0.0
+		for _, s := range supported {
+			typ, subtype, _ := strings.Cut(s, "/")
+			specificity, q := -1, 0.0
+			for _, r := range ranges {
+				if m := r.match(typ, subtype); m > specificity {
+					specificity, q = m, r.q
+				}
+			}
+			if q > bestQ {
+				best, bestQ = s, q
+			}
+		}
+
+		if best == "" {
+			abortWithError(ctx, http.StatusNotAcceptable, "none of the acceptable types can be served, supported: "+strings.Join(supported, ", "))
+			return
+		}
+
+		ctxkeys.SetNegotiatedType(ctx, best)
+		ctx.Next()
+	}
+}
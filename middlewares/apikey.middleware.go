@@ -0,0 +1,85 @@
+package middlewares
+
+import (
+	"net/http"
+	"sync"
+
+	"github.com/gin-gonic/gin"
+	"github.com/skyy/gin-gonic/ctxkeys"
+)
+
+//💡 KeyStore resolves an API key to the principal (user / service) that owns it
+type KeyStore interface {
+	Lookup(key string) (principal string, ok bool)
+}
+
+// MemoryKeyStore keeps keys in memory. A principal can hold several active keys,
+// so a key can be rotated by adding the new one before revoking the old one.
+type MemoryKeyStore struct {
+	mu   sync.RWMutex
+	keys map[string][]string // principal -> active keys
+}
+
+func NewMemoryKeyStore() *MemoryKeyStore {
+	return &MemoryKeyStore{keys: make(map[string][]string)}
+}
+
+func (s *MemoryKeyStore) Add(principal, key string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.keys[principal] = append(s.keys[principal], key)
+}
+
+func (s *MemoryKeyStore) Revoke(principal, key string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	keys := s.keys[principal][:0]
+	for _, k := range s.keys[principal] {
+		if k != key {
+			keys = append(keys, k)
+		}
+	}
+	if len(keys) == 0 {
+		delete(s.keys, principal)
+		return
+	}
+	s.keys[principal] = keys
+}
+
+// Lookup checks every key with secureEqual and never stops early,
+// so timing doesn't reveal how close a guess was.
+func (s *MemoryKeyStore) Lookup(key string) (string, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	found := ""
+	for principal, keys := range s.keys {
+		for _, k := range keys {
+			if secureEqual(key, k) {
+				found = principal
+			}
+		}
+	}
+	return found, found != ""
+}
+
+//💡 API key auth: X-API-Key must belong to a principal in store, otherwise 401
+func APIKeyAuth(store KeyStore) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		key := ctx.GetHeader("X-API-Key")
+		if key == "" {
+			abortWithError(ctx, http.StatusUnauthorized, "API key missing")
+			return
+		}
+
+		principal, ok := store.Lookup(key)
+		if !ok {
+			abortWithError(ctx, http.StatusUnauthorized, "invalid API key")
+			return
+		}
+
+		ctxkeys.SetPrincipal(ctx, ctxkeys.Principal{ID: principal, Method: "api_key"})
+		ctx.Next()
+	}
+}
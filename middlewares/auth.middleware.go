@@ -1,39 +1,65 @@
 package middlewares
 
 import (
+	"crypto/subtle"
 	"net/http"
+	"strings"
 
 	"github.com/gin-gonic/gin"
+	"github.com/skyy/gin-gonic/auth"
+	"github.com/skyy/gin-gonic/i18n"
 )
 
 //💡 auth req-middleware
-func Authenticate(ctx *gin.Context){
-	if !(ctx.Request.Header.Get("Token")=="auth"){
-		ctx.AbortWithStatusJSON(http.StatusInternalServerError,gin.H{
-			"Message":"Token Not Present! 🔴",
-		})
-		return
+// expectedToken is passed at wiring time, headerName defaults to "Token"
+func Authenticate(expectedToken string, headerName ...string) gin.HandlerFunc {
+	header := "Token"
+	if len(headerName) > 0 && headerName[0] != "" {
+		header = headerName[0]
 	}
 
-	ctx.Next()
+	return func(ctx *gin.Context) {
+		token := strings.TrimSpace(ctx.Request.Header.Get(header))
+		if token == "" {
+			token = auth.BearerToken(ctx.Request.Header.Get("Authorization"))
+		}
+		// missing credential -> 401, wrong credential -> 403 (client errors, not 5xx)
+		if token == "" {
+			ctx.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
+				"code":    http.StatusUnauthorized,
+				"Message": i18n.Translate(lang(ctx), "Token Not Present!") + " 🔴",
+			})
+			return
+		}
+		if !secureEqual(token, expectedToken) {
+			ctx.AbortWithStatusJSON(http.StatusForbidden, gin.H{
+				"code":    http.StatusForbidden,
+				"Message": i18n.Translate(lang(ctx), "Invalid Token!") + " 🔴",
+			})
+			return
+		}
+
+		ctx.Next()
+	}
+}
+
+// client's preferred language for error messages (Accept-Language)
+func lang(ctx *gin.Context) string {
+	return i18n.Negotiate(ctx.GetHeader("Accept-Language"))
 }
 
-// 💡Alternate way to write same MW
-//func Authenticate()gin.HandlerFunc{
-	// Write custom logic to be applied before the MW is executed
-// 	return func(ctx *gin.Context){
-// 	if !(ctx.Request.Header.Get("Token")=="auth"){
-// 		ctx.AbortWithStatusJSON(http.StatusInternalServerError,gin.H{
-// 			"Message":"Token Not Present! 🔴",
-// 		})
-// 		return	
-// 	}
-// 		ctx.Next()
-// 	}
-// }
+// constant-time comparison so the secret can't be guessed byte by byte via timing
+func secureEqual(a, b string) bool {
+	return subtle.ConstantTimeCompare([]byte(a), []byte(b)) == 1
+}
 
 // 💡 resp-middleware (runs before the resp. is executed)
-func AddHeader(ctx *gin.Context){
-	ctx.Writer.Header().Set("Key","Val")
-	ctx.Next()
+// sets every header in headers on the response, e.g. {"X-Frame-Options": "DENY"}
+func AddHeaders(headers map[string]string) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		for k, v := range headers {
+			ctx.Writer.Header().Set(k, v)
+		}
+		ctx.Next()
+	}
 }
@@ -0,0 +1,67 @@
+package middlewares
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestAuthenticate(t *testing.T) {
+	r := gin.New()
+	r.GET("/", Authenticate("secret"), func(ctx *gin.Context) {
+		ctx.Status(http.StatusOK)
+	})
+
+	tests := []struct {
+		name    string
+		headers map[string]string
+		want    int
+	}{
+		{"token header", map[string]string{"Token": "secret"}, http.StatusOK},
+		{"lowercase bearer", map[string]string{"Authorization": "bearer secret"}, http.StatusOK},
+		{"extra spaces", map[string]string{"Authorization": "Bearer   secret "}, http.StatusOK},
+		{"missing prefix", map[string]string{"Authorization": "secret"}, http.StatusUnauthorized},
+		{"empty after prefix", map[string]string{"Authorization": "Bearer  "}, http.StatusUnauthorized},
+		{"no credentials", nil, http.StatusUnauthorized},
+		{"wrong token", map[string]string{"Authorization": "Bearer nope"}, http.StatusForbidden},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			for k, v := range tt.headers {
+				req.Header.Set(k, v)
+			}
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+			if w.Code != tt.want {
+				t.Errorf("status = %d, want %d (body %s)", w.Code, tt.want, w.Body)
+			}
+		})
+	}
+}
+
+// The equal-length cases should all cost the same, however many leading bytes match.
+// A different length returns early (ConstantTimeCompare only hides content, not length).
+func BenchmarkSecureEqual(b *testing.B) {
+	secret := strings.Repeat("s", 64)
+	cases := []struct {
+		name  string
+		guess string
+	}{
+		{"equal", secret},
+		{"first byte differs", "x" + secret[1:]},
+		{"last byte differs", secret[:63] + "x"},
+		{"shorter", secret[:32]},
+		{"longer", secret + "x"},
+	}
+	for _, c := range cases {
+		b.Run(c.name, func(b *testing.B) {
+			for b.Loop() {
+				secureEqual(c.guess, secret)
+			}
+		})
+	}
+}
@@ -0,0 +1,33 @@
+package middlewares
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+//💡 Cap request bodies so a huge upload can't exhaust memory.
+// Declared-too-large bodies are rejected up front, the rest are wrapped with
+// http.MaxBytesReader so reads fail with *http.MaxBytesError past n bytes.
+// skipPaths (route templates) aren't limited here, e.g. uploads with their own cap.
+func MaxBodyBytes(n int64, skipPaths ...string) gin.HandlerFunc {
+	skip := make(map[string]bool, len(skipPaths))
+	for _, p := range skipPaths {
+		skip[p] = true
+	}
+
+	return func(ctx *gin.Context) {
+		if skip[ctx.FullPath()] {
+			ctx.Next()
+			return
+		}
+
+		if ctx.Request.ContentLength > n {
+			abortWithError(ctx, http.StatusRequestEntityTooLarge, "request body too large")
+			return
+		}
+
+		ctx.Request.Body = http.MaxBytesReader(ctx.Writer, ctx.Request.Body, n)
+		ctx.Next()
+	}
+}
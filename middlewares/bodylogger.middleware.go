@@ -0,0 +1,80 @@
+package middlewares
+
+import (
+	"bytes"
+	"io"
+	"mime"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/skyy/gin-gonic/ctxkeys"
+)
+
+// textual content types are the only ones worth putting in a log line
+func isTextContentType(contentType string) bool {
+	mt, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+	switch mt {
+	case "application/json", "application/xml", "application/x-www-form-urlencoded", "application/x-yaml", "application/yaml":
+		return true
+	}
+	return strings.HasPrefix(mt, "text/")
+}
+
+type bodyCaptureWriter struct {
+	gin.ResponseWriter
+	buf *bytes.Buffer
+	max int
+}
+
+func (w *bodyCaptureWriter) Write(b []byte) (int, error) {
+	if room := w.max - w.buf.Len(); room > 0 {
+		w.buf.Write(b[:min(room, len(b))])
+	}
+	return w.ResponseWriter.Write(b)
+}
+
+func (w *bodyCaptureWriter) WriteString(s string) (int, error) {
+	return w.Write([]byte(s))
+}
+
+func (w *bodyCaptureWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+//💡 Debug-only: keeps up to maxBytes of the request & response bodies (text types only)
+// so FormatLogsJSON can add them as request_body / response_body, with the RedactKeys
+// fields (password, token ...) masked. Register it after the logger. Off by default
+// because of the extra copying.
+func BodyLogger(maxBytes int) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		if ctx.Request.Body != nil && isTextContentType(ctx.GetHeader("Content-Type")) {
+			buf := make([]byte, maxBytes)
+			n, _ := io.ReadFull(ctx.Request.Body, buf)
+			buf = buf[:n]
+			ctxkeys.SetRequestBody(ctx, redactBody(ctx.GetHeader("Content-Type"), string(buf), RedactKeys))
+
+			// put back what we read so handlers still see the whole body
+			ctx.Request.Body = readCloser{io.MultiReader(bytes.NewReader(buf), ctx.Request.Body), ctx.Request.Body}
+		}
+
+		orig := ctx.Writer
+		cw := &bodyCaptureWriter{ResponseWriter: orig, buf: &bytes.Buffer{}, max: maxBytes}
+		ctx.Writer = cw
+
+		ctx.Next()
+
+		ctx.Writer = orig
+		if cw.buf.Len() > 0 && isTextContentType(orig.Header().Get("Content-Type")) {
+			ctxkeys.SetResponseBody(ctx, redactBody(orig.Header().Get("Content-Type"), cw.buf.String(), RedactKeys))
+		}
+	}
+}
+
+type readCloser struct {
+	io.Reader
+	io.Closer
+}
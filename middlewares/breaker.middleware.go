@@ -0,0 +1,132 @@
+package middlewares
+
+import (
+	"math"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+const (
+	breakerClosed   = 0
+	breakerOpen     = 1
+	breakerHalfOpen = 2
+)
+
+var circuitBreakerState = promauto.NewGaugeVec(prometheus.GaugeOpts{
+	Name: "circuit_breaker_state",
+	Help: "Circuit breaker state by name: 0 closed, 1 open, 2 half-open.",
+}, []string{"name"})
+
+// BreakerOptions tunes CircuitBreaker, zero values get the defaults
+type BreakerOptions struct {
+	FailureThreshold int           // consecutive failures that open the circuit, default 5
+	Cooldown         time.Duration // how long it stays open before one probe is let through, default 30s
+	// IsFailure says whether a finished request was a downstream failure, default any 5xx.
+	// Panics always count.
+	IsFailure func(ctx *gin.Context) bool
+}
+
+type breaker struct {
+	mu       sync.Mutex
+	name     string
+	opts     BreakerOptions
+	state    int
+	failures int
+	openedAt time.Time
+	probing  bool // half-open: a probe is in flight, everyone else still gets the 503
+}
+
+func (b *breaker) setState(state int) {
+	b.state = state
+	circuitBreakerState.WithLabelValues(b.name).Set(float64(state))
+}
+
+// allow says whether the request may go through, and if not for how long the circuit stays open
+func (b *breaker) allow() (bool, time.Duration) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case breakerOpen:
+		if wait := b.opts.Cooldown - time.Since(b.openedAt); wait > 0 {
+			return false, wait
+		}
+		b.setState(breakerHalfOpen)
+		b.probing = true
+		return true, 0
+	case breakerHalfOpen:
+		if b.probing {
+			return false, time.Second
+		}
+		b.probing = true
+		return true, 0
+	}
+	return true, 0
+}
+
+func (b *breaker) record(failed bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if !failed {
+		b.failures = 0
+		b.probing = false
+		if b.state != breakerClosed {
+			b.setState(breakerClosed)
+		}
+		return
+	}
+
+	b.failures++
+	if b.state == breakerHalfOpen || b.failures >= b.opts.FailureThreshold {
+		b.probing = false
+		b.openedAt = time.Now()
+		b.setState(breakerOpen)
+	}
+}
+
+//💡 CircuitBreaker protects a route that depends on a flaky downstream: after FailureThreshold
+// failures in a row (5xx responses or panics, see IsFailure) the circuit opens and requests get a 503 right
+// away, without touching the downstream. After Cooldown one probe request goes through
+// (half-open): success closes the circuit, failure opens it again.
+// The state is exported as circuit_breaker_state{name} on /metrics.
+func CircuitBreaker(name string, opts BreakerOptions) gin.HandlerFunc {
+	if opts.FailureThreshold <= 0 {
+		opts.FailureThreshold = 5
+	}
+	if opts.Cooldown <= 0 {
+		opts.Cooldown = 30 * time.Second
+	}
+	if opts.IsFailure == nil {
+		opts.IsFailure = func(ctx *gin.Context) bool {
+			return ctx.Writer.Status() >= http.StatusInternalServerError
+		}
+	}
+	b := &breaker{name: name, opts: opts}
+	b.setState(breakerClosed)
+
+	return func(ctx *gin.Context) {
+		ok, wait := b.allow()
+		if !ok {
+			ctx.Header("Retry-After", strconv.Itoa(max(1, int(math.Ceil(wait.Seconds())))))
+			abortWithError(ctx, http.StatusServiceUnavailable, name+" is unavailable, try again later")
+			return
+		}
+
+		// a panic counts as a failure, then goes on up to RecoveryJSON
+		defer func() {
+			if r := recover(); r != nil {
+				b.record(true)
+				panic(r)
+			}
+		}()
+		ctx.Next()
+		b.record(opts.IsFailure(ctx))
+	}
+}
@@ -0,0 +1,248 @@
+package middlewares
+
+import (
+	"bytes"
+	"container/list"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+const defaultCacheEntries = 1000
+
+type cachedResponse struct {
+	key    string
+	status int
+	header http.Header
+	body   []byte
+	stored time.Time
+}
+
+// responseCache is a fixed-size LRU, the front of order is the most recently used entry
+type responseCache struct {
+	mu      sync.Mutex
+	max     int
+	order   *list.List
+	entries map[string]*list.Element
+}
+
+func (c *responseCache) get(key string, ttl time.Duration) (*cachedResponse, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	el, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	entry := el.Value.(*cachedResponse)
+	if time.Since(entry.stored) >= ttl {
+		c.order.Remove(el)
+		delete(c.entries, key)
+		return nil, false
+	}
+	c.order.MoveToFront(el)
+	return entry, true
+}
+
+func (c *responseCache) put(entry *cachedResponse) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if el, ok := c.entries[entry.key]; ok {
+		el.Value = entry
+		c.order.MoveToFront(el)
+		return
+	}
+	c.entries[entry.key] = c.order.PushFront(entry)
+	for c.order.Len() > c.max {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*cachedResponse).key)
+	}
+}
+
+func (c *responseCache) purge() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.order.Init()
+	clear(c.entries)
+}
+
+// cacheWriter passes the response through while keeping a copy of it. The headers
+// are snapshotted at the first write, before outer writers (Server-Timing ...) add theirs.
+type cacheWriter struct {
+	gin.ResponseWriter
+	ttl    time.Duration
+	before http.Header
+	header http.Header
+	buf    bytes.Buffer
+}
+
+func (w *cacheWriter) snapshot() {
+	if w.header != nil {
+		return
+	}
+	// only what the handler (or middlewares after Cache) set, not X-Request-ID & co.
+	w.header = changedHeaders(w.before, w.ResponseWriter.Header())
+	addVary(w.ResponseWriter.Header(), "Accept") // the key includes Accept, shared caches must know
+
+	// fresh copy, tell clients how long they may keep it (not stored, hits compute their own)
+	if w.Status() == http.StatusOK && w.header.Get("Cache-Control") == "" {
+		w.ResponseWriter.Header().Set("Cache-Control", fmt.Sprintf("public, max-age=%d", int(w.ttl.Seconds())))
+		w.ResponseWriter.Header().Set("Age", "0")
+	}
+}
+
+// copies the headers in now that are new or different from before
+func changedHeaders(before, now http.Header) http.Header {
+	changed := http.Header{}
+	for k, v := range now {
+		if prev, ok := before[k]; !ok || strings.Join(prev, "\x00") != strings.Join(v, "\x00") {
+			changed[k] = append([]string(nil), v...)
+		}
+	}
+	return changed
+}
+
+// addVary appends field to Vary unless it (or "*") is already listed, CORS & Gzip add their own
+func addVary(h http.Header, field string) {
+	for _, v := range h.Values("Vary") {
+		for _, f := range strings.Split(v, ",") {
+			if f = strings.TrimSpace(f); f == "*" || strings.EqualFold(f, field) {
+				return
+			}
+		}
+	}
+	h.Add("Vary", field)
+}
+
+func (w *cacheWriter) WriteHeaderNow() {
+	w.snapshot()
+	w.ResponseWriter.WriteHeaderNow()
+}
+
+func (w *cacheWriter) Write(b []byte) (int, error) {
+	w.snapshot()
+	w.buf.Write(b)
+	return w.ResponseWriter.Write(b)
+}
+
+func (w *cacheWriter) WriteString(s string) (int, error) {
+	return w.Write([]byte(s))
+}
+
+func (w *cacheWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+//💡 Cache keeps full 200 responses of GET routes in memory (LRU, maxEntries defaults to 1000)
+// and replays them until ttl runs out, with Age / Cache-Control / X-Cache headers.
+// Requests carrying credentials and responses that set cookies or say no-store/private are
+// never cached. Call the returned purge func to drop everything (e.g. after a deploy).
+func Cache(ttl time.Duration, maxEntries ...int) (gin.HandlerFunc, func()) {
+	c := &responseCache{
+		max:     defaultCacheEntries,
+		order:   list.New(),
+		entries: map[string]*list.Element{},
+	}
+	if len(maxEntries) > 0 && maxEntries[0] > 0 {
+		c.max = maxEntries[0]
+	}
+
+	handler := func(ctx *gin.Context) {
+		if (ctx.Request.Method != http.MethodGet && ctx.Request.Method != http.MethodHead) || hasCredentials(ctx.Request) {
+			ctx.Next()
+			return
+		}
+
+		// Accept is part of the key, Respond renders JSON / XML / YAML from it
+		key := ctx.Request.Method + " " + ctx.Request.URL.RequestURI() + " " + ctx.GetHeader("Accept")
+		// and so is gzip support, ETag tags the gzipped representation differently
+		if AcceptsEncoding(ctx.GetHeader("Accept-Encoding"), "gzip") {
+			key += " gzip"
+		}
+		if entry, ok := c.get(key, ttl); ok {
+			serveCached(ctx, entry, ttl)
+			return
+		}
+
+		orig := ctx.Writer
+		cw := &cacheWriter{ResponseWriter: orig, ttl: ttl, before: orig.Header().Clone()}
+		orig.Header().Set("X-Cache", "MISS")
+		ctx.Writer = cw
+		ctx.Next()
+		ctx.Writer = orig
+
+		if orig.Status() != http.StatusOK || cw.header == nil || !cacheable(cw.header) {
+			return
+		}
+		delete(cw.header, "X-Cache")
+		c.put(&cachedResponse{
+			key:    key,
+			status: orig.Status(),
+			header: cw.header,
+			body:   bytes.Clone(cw.buf.Bytes()),
+			stored: time.Now(),
+		})
+	}
+	return handler, c.purge
+}
+
+func serveCached(ctx *gin.Context, entry *cachedResponse, ttl time.Duration) {
+	age := time.Since(entry.stored)
+	h := ctx.Writer.Header()
+	for k, v := range entry.header {
+		if k == "Vary" {
+			continue // merged below, the outer middlewares may have set their own
+		}
+		h[k] = append([]string(nil), v...)
+	}
+	for _, v := range entry.header.Values("Vary") {
+		for _, f := range strings.Split(v, ",") {
+			if f = strings.TrimSpace(f); f != "" {
+				addVary(h, f)
+			}
+		}
+	}
+	addVary(h, "Accept")
+	h.Set("Age", strconv.Itoa(int(age.Seconds())))
+	if h.Get("Cache-Control") == "" {
+		h.Set("Cache-Control", fmt.Sprintf("public, max-age=%d", int((ttl-age).Seconds())))
+	}
+	h.Set("X-Cache", "HIT")
+
+	if etag := h.Get("ETag"); etag != "" && etagMatches(ctx.GetHeader("If-None-Match"), etag) {
+		h.Del("Content-Type")
+		h.Del("Content-Length")
+		ctx.AbortWithStatus(http.StatusNotModified)
+		return
+	}
+
+	ctx.Status(entry.status)
+	if ctx.Request.Method != http.MethodHead {
+		ctx.Writer.Write(entry.body)
+	}
+	ctx.Abort()
+}
+
+func hasCredentials(r *http.Request) bool {
+	for _, k := range []string{"Authorization", "Token", "X-API-Key", "Cookie"} {
+		if r.Header.Get(k) != "" {
+			return true
+		}
+	}
+	return false
+}
+
+func cacheable(h http.Header) bool {
+	if h.Get("Set-Cookie") != "" {
+		return false
+	}
+	cc := strings.ToLower(h.Get("Cache-Control"))
+	return !strings.Contains(cc, "no-store") && !strings.Contains(cc, "private")
+}
@@ -0,0 +1,37 @@
+package middlewares
+
+import (
+	"fmt"
+
+	"github.com/gin-gonic/gin"
+)
+
+//💡 Chain bundles middlewares into one named, ordered stack for router.Use / Group:
+//
+//	limit, stopLimit := middlewares.RateLimit(5, 10)
+//	defer stopLimit()
+//	adminStack := middlewares.Chain(
+//		limit,                            // cheapest rejection first
+//		jwt.JWTAuth(secret),              // who is it?
+//		middlewares.RequireRole("admin"), // may they?
+//	)
+//	router.Group("/admin", adminStack...)
+//
+// Order rules used in main.go (first = outermost):
+//  1. RequestID, so everything below (logs, traces, errors) can use the ID
+//  2. access logs & metrics, before anything that may abort, so rejections are still logged
+//  3. RecoveryJSON, inside the logs & metrics so a panicked request is still logged and counted
+//  4. limits (ConcurrencyLimit, MaxBodyBytes, RateLimit), then auth, then per-route checks
+//
+// An abort anywhere stops everything after it, including the route handler.
+// It returns a gin.HandlersChain rather than a single HandlerFunc on purpose:
+// gin's ctx.Next() only walks the router's own chain, so middlewares that wrap
+// Next (Logger, Timeout, Authenticate ...) can't be nested inside one handler.
+func Chain(handlers ...gin.HandlerFunc) gin.HandlersChain {
+	for i, h := range handlers {
+		if h == nil {
+			panic(fmt.Sprintf("⚠️middlewares.Chain: handler %d is nil", i))
+		}
+	}
+	return gin.HandlersChain(handlers)
+}
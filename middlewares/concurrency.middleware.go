@@ -0,0 +1,52 @@
+package middlewares
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+//💡 ConcurrencyLimit caps requests in flight at max (semaphore = buffered channel).
+// With no wait a request over the limit gets an immediate 503, with wait it queues
+// for up to that long (or until the client leaves) before giving up.
+func ConcurrencyLimit(max int, wait ...time.Duration) gin.HandlerFunc {
+	sem := make(chan struct{}, max)
+	var maxWait time.Duration
+	if len(wait) > 0 {
+		maxWait = wait[0]
+	}
+
+	return func(ctx *gin.Context) {
+		if !acquire(ctx, sem, maxWait) {
+			ctx.Header("Retry-After", "1")
+			abortWithError(ctx, http.StatusServiceUnavailable, "server busy, try again shortly")
+			return
+		}
+		defer func() { <-sem }() // deferred so a panic further down doesn't leak the slot
+
+		ctx.Next()
+	}
+}
+
+func acquire(ctx *gin.Context, sem chan struct{}, wait time.Duration) bool {
+	select {
+	case sem <- struct{}{}:
+		return true
+	default:
+	}
+	if wait <= 0 {
+		return false
+	}
+
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+	select {
+	case sem <- struct{}{}:
+		return true
+	case <-timer.C:
+		return false
+	case <-ctx.Request.Context().Done():
+		return false
+	}
+}
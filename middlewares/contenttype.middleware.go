@@ -0,0 +1,35 @@
+package middlewares
+
+import (
+	"mime"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+//💡 Only let POST/PUT/PATCH through when Content-Type is one of types, otherwise 415.
+// Parameters are ignored, so "application/json; charset=utf-8" matches "application/json".
+func RequireContentType(types ...string) gin.HandlerFunc {
+	allowed := make(map[string]bool, len(types))
+	for _, t := range types {
+		allowed[strings.ToLower(t)] = true
+	}
+
+	return func(ctx *gin.Context) {
+		switch ctx.Request.Method {
+		case http.MethodPost, http.MethodPut, http.MethodPatch:
+		default:
+			ctx.Next()
+			return
+		}
+
+		mediaType, _, err := mime.ParseMediaType(ctx.GetHeader("Content-Type"))
+		if err != nil || !allowed[mediaType] {
+			abortWithError(ctx, http.StatusUnsupportedMediaType, "Content-Type must be one of: "+strings.Join(types, ", "))
+			return
+		}
+
+		ctx.Next()
+	}
+}
@@ -0,0 +1,74 @@
+package middlewares
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+//💡 CORS mw (lets a front-end on another origin call us)
+type CORSOptions struct {
+	AllowOrigins     []string
+	AllowMethods     []string
+	AllowHeaders     []string
+	AllowCredentials bool
+}
+
+func CORS(opts CORSOptions) gin.HandlerFunc {
+	allowAll := false
+	origins := make(map[string]bool, len(opts.AllowOrigins))
+	for _, o := range opts.AllowOrigins {
+		if o == "*" {
+			allowAll = true
+		}
+		origins[o] = true
+	}
+
+	methods := strings.Join(opts.AllowMethods, ", ")
+	headers := strings.Join(opts.AllowHeaders, ", ")
+
+	return func(ctx *gin.Context) {
+		origin := ctx.Request.Header.Get("Origin")
+		if origin == "" {
+			// not a cross-origin request
+			ctx.Next()
+			return
+		}
+
+		h := ctx.Writer.Header()
+		switch {
+		case allowAll && !opts.AllowCredentials:
+			h.Set("Access-Control-Allow-Origin", "*")
+		case allowAll || origins[origin]:
+			// browsers reject "*" together with credentials, so reflect the origin
+			h.Set("Access-Control-Allow-Origin", origin)
+			h.Add("Vary", "Origin")
+		default:
+			if ctx.Request.Method == http.MethodOptions {
+				ctx.AbortWithStatus(http.StatusNoContent)
+				return
+			}
+			ctx.Next()
+			return
+		}
+
+		if opts.AllowCredentials {
+			h.Set("Access-Control-Allow-Credentials", "true")
+		}
+
+		// preflight
+		if ctx.Request.Method == http.MethodOptions {
+			if methods != "" {
+				h.Set("Access-Control-Allow-Methods", methods)
+			}
+			if headers != "" {
+				h.Set("Access-Control-Allow-Headers", headers)
+			}
+			ctx.AbortWithStatus(http.StatusNoContent)
+			return
+		}
+
+		ctx.Next()
+	}
+}
@@ -0,0 +1,20 @@
+package middlewares
+
+import (
+	"context"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+//💡 WithDeadline bounds ctx.Request.Context() to d, so DB/HTTP calls made with it give up in time.
+// Unlike Timeout it doesn't cut the response, the handler decides what to do with context.DeadlineExceeded.
+func WithDeadline(d time.Duration) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		reqCtx, cancel := context.WithTimeout(ctx.Request.Context(), d)
+		defer cancel() // release the timer once the chain is done
+
+		ctx.Request = ctx.Request.WithContext(reqCtx)
+		ctx.Next()
+	}
+}
@@ -0,0 +1,67 @@
+package middlewares
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestWithDeadlinePropagates(t *testing.T) {
+	const d = 2 * time.Second
+
+	var (
+		deadline    time.Time
+		hasDeadline bool
+		reqCtx      context.Context
+	)
+	r := gin.New()
+	r.GET("/", WithDeadline(d), func(ctx *gin.Context) {
+		reqCtx = ctx.Request.Context()
+		deadline, hasDeadline = reqCtx.Deadline()
+		ctx.Status(http.StatusOK)
+	})
+
+	before := time.Now()
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+	after := time.Now()
+
+	if !hasDeadline {
+		t.Fatal("handler's request context has no deadline")
+	}
+	if deadline.Before(before.Add(d)) || deadline.After(after.Add(d)) {
+		t.Errorf("deadline %s not within %s of the request", deadline.Format(time.RFC3339Nano), d)
+	}
+	if reqCtx.Err() == nil {
+		t.Error("request context not cancelled once the chain returned")
+	}
+}
+
+func TestWithDeadlineExpires(t *testing.T) {
+	r := gin.New()
+	r.GET("/", WithDeadline(10*time.Millisecond), func(ctx *gin.Context) {
+		// stands in for a DB / HTTP call that takes longer than the deadline
+		select {
+		case <-ctx.Request.Context().Done():
+			if errors.Is(ctx.Request.Context().Err(), context.DeadlineExceeded) {
+				ctx.Status(http.StatusGatewayTimeout)
+				return
+			}
+			ctx.Status(http.StatusInternalServerError)
+		case <-time.After(time.Second):
+			ctx.Status(http.StatusOK)
+		}
+	})
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	if w.Code != http.StatusGatewayTimeout {
+		t.Errorf("status = %d, want 504 from a handler that saw DeadlineExceeded", w.Code)
+	}
+}
@@ -0,0 +1,69 @@
+package middlewares
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+const echoHeader = "X-Debug-Echo"
+
+// besides RedactKeys, credentials that only show up in a full header dump
+var echoRedactKeys = append([]string{"Cookie", "X-API-Key"}, RedactKeys...)
+
+//💡 Debug-only: a request with "X-Debug-Echo: true" isn't handled, it gets back what we
+// received (method, path, query, headers, body) as JSON, to see what a client integration
+// really sends. Secrets named in RedactKeys (+ Cookie, X-API-Key) are masked. Register it
+// globally and only outside production, it answers before auth does.
+func Echo() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		if !strings.EqualFold(ctx.GetHeader(echoHeader), "true") {
+			ctx.Next()
+			return
+		}
+
+		var body string
+		if ctx.Request.Body != nil {
+			raw, err := io.ReadAll(ctx.Request.Body)
+			switch {
+			case err != nil:
+				body = "⚠️failed to read body: " + err.Error()
+			case len(raw) == 0:
+			case isTextContentType(ctx.GetHeader("Content-Type")):
+				body = string(raw)
+			default:
+				body = fmt.Sprintf("<%d bytes of %s>", len(raw), ctx.GetHeader("Content-Type"))
+			}
+		}
+
+		ctx.AbortWithStatusJSON(http.StatusOK, gin.H{
+			"method":    ctx.Request.Method,
+			"path":      ctx.Request.URL.Path,
+			"route":     ctx.FullPath(), // "" when nothing matched
+			"query":     redactValues(ctx.Request.URL.Query(), echoRedactKeys),
+			"headers":   redactValues(url.Values(ctx.Request.Header.Clone()), echoRedactKeys),
+			"body":      body,
+			"client_ip": ctx.ClientIP(),
+			"proto":     ctx.Request.Proto,
+		})
+	}
+}
+
+// masks every value whose key is in keys (case-insensitively), in place
+func redactValues(values url.Values, keys []string) url.Values {
+	for k, vs := range values {
+		for _, key := range keys {
+			if strings.EqualFold(k, key) {
+				for i := range vs {
+					vs[i] = redacted
+				}
+				break
+			}
+		}
+	}
+	return values
+}
@@ -0,0 +1,17 @@
+package middlewares
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/skyy/gin-gonic/i18n"
+)
+
+// errorBody is the {"code","message"} shape handlers.APIError renders, so a client sees the
+// same error whether a middleware or a handler turned the request away
+func errorBody(code int, msg string) gin.H {
+	return gin.H{"code": code, "message": msg}
+}
+
+// abortWithError stops the chain with an errorBody, msg translated like handlers.AbortWithError does
+func abortWithError(ctx *gin.Context, code int, msg string) {
+	ctx.AbortWithStatusJSON(code, errorBody(code, i18n.Translate(lang(ctx), msg)))
+}
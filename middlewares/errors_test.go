@@ -0,0 +1,191 @@
+package middlewares
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// every middleware rejection renders {"code","message"}, the shape of handlers.APIError
+func TestRejectionShape(t *testing.T) {
+	tests := []struct {
+		name       string
+		middleware gin.HandlerFunc
+		handler    gin.HandlerFunc // defaults to a 200
+		request    func() *http.Request
+		want       int
+	}{
+		{
+			name:       "MaxBodyBytes",
+			middleware: MaxBodyBytes(4),
+			request: func() *http.Request {
+				return httptest.NewRequest(http.MethodPost, "/", strings.NewReader("too long"))
+			},
+			want: http.StatusRequestEntityTooLarge,
+		},
+		{
+			name: "RateLimit",
+			middleware: func() gin.HandlerFunc {
+				limit, stop := RateLimit(1, 0)
+				stop()
+				return limit
+			}(),
+			request: func() *http.Request { return httptest.NewRequest(http.MethodGet, "/", nil) },
+			want:    http.StatusTooManyRequests,
+		},
+		{
+			name:       "Recovery",
+			middleware: RecoveryJSON(),
+			handler:    func(*gin.Context) { panic("boom") },
+			request:    func() *http.Request { return httptest.NewRequest(http.MethodGet, "/", nil) },
+			want:       http.StatusInternalServerError,
+		},
+		{
+			name:       "RequireContentType",
+			middleware: RequireContentType("application/json"),
+			request: func() *http.Request {
+				req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader("x"))
+				req.Header.Set("Content-Type", "text/plain")
+				return req
+			},
+			want: http.StatusUnsupportedMediaType,
+		},
+		{
+			name:       "MaxHeaders",
+			middleware: MaxHeaders(1),
+			request: func() *http.Request {
+				req := httptest.NewRequest(http.MethodGet, "/", nil)
+				req.Header.Add("X-A", "1")
+				req.Header.Add("X-A", "2")
+				return req
+			},
+			want: http.StatusRequestHeaderFieldsTooLarge,
+		},
+		{
+			name:       "ConcurrencyLimit",
+			middleware: ConcurrencyLimit(0),
+			request:    func() *http.Request { return httptest.NewRequest(http.MethodGet, "/", nil) },
+			want:       http.StatusServiceUnavailable,
+		},
+		{
+			name: "MaintenanceMode",
+			middleware: func() gin.HandlerFunc {
+				var on atomic.Bool
+				on.Store(true)
+				return MaintenanceMode(&on, time.Second)
+			}(),
+			request: func() *http.Request { return httptest.NewRequest(http.MethodPost, "/", nil) },
+			want:    http.StatusServiceUnavailable,
+		},
+		{
+			name:       "Idempotency without a key",
+			middleware: Idempotency(NewMemoryIdempotencyStore(time.Minute)),
+			request:    func() *http.Request { return httptest.NewRequest(http.MethodPost, "/", nil) },
+			want:       http.StatusBadRequest,
+		},
+		{
+			name:       "Idempotency while in flight",
+			middleware: Idempotency(inFlightStore{}),
+			request: func() *http.Request {
+				req := httptest.NewRequest(http.MethodPost, "/", nil)
+				req.Header.Set("Idempotency-Key", "k")
+				return req
+			},
+			want: http.StatusConflict,
+		},
+		{
+			name: "CircuitBreaker open",
+			middleware: func() gin.HandlerFunc {
+				cb := CircuitBreaker("rejection-shape", BreakerOptions{FailureThreshold: 1, Cooldown: time.Hour})
+				r := gin.New()
+				r.GET("/", cb, func(ctx *gin.Context) { ctx.Status(http.StatusBadGateway) })
+				r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+				return cb
+			}(),
+			request: func() *http.Request { return httptest.NewRequest(http.MethodGet, "/", nil) },
+			want:    http.StatusServiceUnavailable,
+		},
+		{
+			name:       "TenantResolver",
+			middleware: TenantResolver(TenantFromHeader("X-Tenant-ID")),
+			request:    func() *http.Request { return httptest.NewRequest(http.MethodGet, "/", nil) },
+			want:       http.StatusBadRequest,
+		},
+		{
+			name: "ThrottleOnFailure banned",
+			middleware: func() gin.HandlerFunc {
+				throttle, stop := ThrottleOnFailure(1, time.Minute, time.Hour)
+				stop()
+				r := gin.New()
+				r.GET("/", throttle, func(ctx *gin.Context) { ctx.Status(http.StatusUnauthorized) })
+				r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+				return throttle
+			}(),
+			request: func() *http.Request { return httptest.NewRequest(http.MethodGet, "/", nil) },
+			want:    http.StatusTooManyRequests,
+		},
+		{
+			name:       "Timeout",
+			middleware: Timeout(10 * time.Millisecond),
+			handler:    func(ctx *gin.Context) { <-ctx.Request.Context().Done() },
+			request:    func() *http.Request { return httptest.NewRequest(http.MethodGet, "/", nil) },
+			want:       http.StatusServiceUnavailable,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := tt.handler
+			if handler == nil {
+				handler = func(ctx *gin.Context) { ctx.Status(http.StatusOK) }
+			}
+			r := gin.New()
+			r.Any("/", tt.middleware, handler)
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, tt.request())
+
+			if w.Code != tt.want {
+				t.Fatalf("status = %d, want %d (body %s)", w.Code, tt.want, w.Body)
+			}
+			var body map[string]any
+			if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+				t.Fatalf("body %s: %v", w.Body, err)
+			}
+			if code, _ := body["code"].(float64); int(code) != tt.want {
+				t.Errorf("code = %v, want %d", body["code"], tt.want)
+			}
+			if msg, _ := body["message"].(string); msg == "" {
+				t.Errorf("message missing in %s", w.Body)
+			}
+			if len(body) != 2 {
+				t.Errorf("body %s, want only code and message", w.Body)
+			}
+		})
+	}
+}
+
+func TestRejectionTranslated(t *testing.T) {
+	r := gin.New()
+	r.POST("/", MaxBodyBytes(4), func(ctx *gin.Context) { ctx.Status(http.StatusOK) })
+	req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader("too long"))
+	req.Header.Set("Accept-Language", "es")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	var body struct{ Message string }
+	json.Unmarshal(w.Body.Bytes(), &body)
+	if want := "el cuerpo de la solicitud es demasiado grande"; body.Message != want {
+		t.Errorf("message = %q, want %q", body.Message, want)
+	}
+}
+
+// inFlightStore has every key claimed by a request that's still running
+type inFlightStore struct{}
+
+func (inFlightStore) Reserve(string) (*IdempotentResponse, bool) { return nil, false }
+func (inFlightStore) Complete(string, *IdempotentResponse)       {}
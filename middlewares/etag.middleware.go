@@ -0,0 +1,108 @@
+package middlewares
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// etagWriter holds the body back until the handler is done so it can be hashed.
+// Past max bytes it gives up and streams everything straight through.
+type etagWriter struct {
+	gin.ResponseWriter
+	buf      bytes.Buffer
+	max      int
+	overflow bool
+}
+
+func (w *etagWriter) Write(b []byte) (int, error) {
+	if w.overflow {
+		return w.ResponseWriter.Write(b)
+	}
+	if w.buf.Len()+len(b) > w.max {
+		w.overflow = true
+		if _, err := w.ResponseWriter.Write(w.buf.Bytes()); err != nil {
+			return 0, err
+		}
+		w.buf.Reset()
+		return w.ResponseWriter.Write(b)
+	}
+	return w.buf.Write(b)
+}
+
+func (w *etagWriter) WriteString(s string) (int, error) {
+	return w.Write([]byte(s))
+}
+
+func (w *etagWriter) Written() bool {
+	return w.overflow || w.buf.Len() > 0 || w.ResponseWriter.Written()
+}
+
+func (w *etagWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+//💡 ETag + conditional GET: hash the (buffered) body, answer 304 when If-None-Match matches.
+// Bodies bigger than maxBytes (default 1 MB) are sent untagged instead of buffered.
+// Clients that accept gzip get a "-gzip" tag, Gzip compresses what they receive.
+func ETag(maxBytes ...int) gin.HandlerFunc {
+	limit := 1 << 20
+	if len(maxBytes) > 0 && maxBytes[0] > 0 {
+		limit = maxBytes[0]
+	}
+
+	return func(ctx *gin.Context) {
+		if ctx.Request.Method != http.MethodGet && ctx.Request.Method != http.MethodHead {
+			ctx.Next()
+			return
+		}
+
+		orig := ctx.Writer
+		ew := &etagWriter{ResponseWriter: orig, max: limit}
+		ctx.Writer = ew
+		ctx.Next()
+		ctx.Writer = orig
+
+		if ew.overflow {
+			return
+		}
+		if orig.Status() != http.StatusOK || ew.buf.Len() == 0 {
+			orig.Write(ew.buf.Bytes())
+			return
+		}
+
+		sum := sha256.Sum256(ew.buf.Bytes())
+		tag := hex.EncodeToString(sum[:16])
+		// Gzip in front sends these clients other bytes: a representation with a tag of its
+		// own, and caches have to key on Accept-Encoding
+		if AcceptsEncoding(ctx.GetHeader("Accept-Encoding"), "gzip") {
+			tag += "-gzip"
+		}
+		etag := `"` + tag + `"`
+		orig.Header().Set("ETag", etag)
+		addVary(orig.Header(), "Accept-Encoding")
+
+		if etagMatches(ctx.GetHeader("If-None-Match"), etag) {
+			orig.Header().Del("Content-Type")
+			orig.Header().Del("Content-Length")
+			orig.WriteHeader(http.StatusNotModified)
+			orig.WriteHeaderNow()
+			return
+		}
+		orig.Write(ew.buf.Bytes())
+	}
+}
+
+func etagMatches(ifNoneMatch, etag string) bool {
+	for _, candidate := range strings.Split(ifNoneMatch, ",") {
+		candidate = strings.TrimPrefix(strings.TrimSpace(candidate), "W/")
+		if candidate == etag || candidate == "*" {
+			return true
+		}
+	}
+	return false
+}
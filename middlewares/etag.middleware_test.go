@@ -0,0 +1,70 @@
+package middlewares
+
+import (
+	"compress/gzip"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// the identity and the gzipped body are different representations, so they must not share a
+// tag, not even when Cache replays them (the way GET / is set up)
+func TestETagWithGzip(t *testing.T) {
+	cache, _ := Cache(time.Minute)
+	r := gin.New()
+	r.Use(Gzip(gzip.DefaultCompression))
+	r.GET("/", cache, ETag(), func(ctx *gin.Context) {
+		ctx.JSON(http.StatusOK, gin.H{"data": strings.Repeat("x", 100)})
+	})
+	get := func(acceptEncoding, ifNoneMatch string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		if acceptEncoding != "" {
+			req.Header.Set("Accept-Encoding", acceptEncoding)
+		}
+		if ifNoneMatch != "" {
+			req.Header.Set("If-None-Match", ifNoneMatch)
+		}
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		return w
+	}
+
+	plain := get("", "")
+	gzipped := get("gzip", "")
+	plainTag, gzipTag := plain.Header().Get("ETag"), gzipped.Header().Get("ETag")
+
+	if gzipped.Header().Get("Content-Encoding") != "gzip" {
+		t.Fatalf("Content-Encoding = %q, want gzip", gzipped.Header().Get("Content-Encoding"))
+	}
+	if plainTag == "" || plainTag == gzipTag {
+		t.Errorf("ETag identity %s, gzip %s, want two different tags", plainTag, gzipTag)
+	}
+	for name, w := range map[string]*httptest.ResponseRecorder{"identity": plain, "gzip": gzipped} {
+		if vary := strings.Join(w.Header().Values("Vary"), ","); strings.Count(vary, "Accept-Encoding") != 1 {
+			t.Errorf("%s: Vary = %q, want Accept-Encoding in it once", name, vary)
+		}
+	}
+
+	tests := []struct {
+		name           string
+		acceptEncoding string
+		ifNoneMatch    string
+		want           int
+	}{
+		{"identity tag, identity client", "", plainTag, http.StatusNotModified},
+		{"gzip tag, gzip client", "gzip", gzipTag, http.StatusNotModified},
+		{"identity tag, gzip client", "gzip", plainTag, http.StatusOK},
+		{"gzip tag, identity client", "", gzipTag, http.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if w := get(tt.acceptEncoding, tt.ifNoneMatch); w.Code != tt.want {
+				t.Errorf("status = %d, want %d", w.Code, tt.want)
+			}
+		})
+	}
+}
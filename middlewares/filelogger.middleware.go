@@ -0,0 +1,120 @@
+package middlewares
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sync"
+
+	"github.com/gin-gonic/gin"
+)
+
+//💡 File logger with size based rotation.
+// path -> path.1 -> path.2 ... (the newest backup is always path.1)
+type rotatingFile struct {
+	mu      sync.Mutex // serializes writes so lines from concurrent requests don't interleave
+	path    string
+	maxSize int64
+	size    int64
+	file    *os.File
+}
+
+func openRotatingFile(path string, maxSizeMB int) (*rotatingFile, error) {
+	r := &rotatingFile{
+		path:    path,
+		maxSize: int64(maxSizeMB) * 1024 * 1024,
+	}
+	if err := r.open(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+func (r *rotatingFile) open() error {
+	f, err := os.OpenFile(r.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0666)
+	if err != nil {
+		return err
+	}
+	info, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return err
+	}
+	r.file = f
+	r.size = info.Size()
+	return nil
+}
+
+// shift every backup up by one, then move the current file to path.1
+func (r *rotatingFile) rotate() error {
+	if err := r.file.Close(); err != nil {
+		return err
+	}
+
+	last := 0
+	for {
+		if _, err := os.Stat(fmt.Sprintf("%s.%d", r.path, last+1)); err != nil {
+			break
+		}
+		last++
+	}
+	for i := last; i >= 1; i-- {
+		if err := os.Rename(fmt.Sprintf("%s.%d", r.path, i), fmt.Sprintf("%s.%d", r.path, i+1)); err != nil {
+			return err
+		}
+	}
+	if err := os.Rename(r.path, r.path+".1"); err != nil {
+		return err
+	}
+
+	return r.open()
+}
+
+func (r *rotatingFile) Write(p []byte) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.maxSize > 0 && r.size > 0 && r.size+int64(len(p)) > r.maxSize {
+		if err := r.rotate(); err != nil {
+			return 0, err
+		}
+	}
+
+	n, err := r.file.Write(p)
+	r.size += int64(n)
+	return n, err
+}
+
+func (r *rotatingFile) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if err := r.file.Sync(); err != nil {
+		r.file.Close()
+		return err
+	}
+	return r.file.Close()
+}
+
+// OpenLogFile opens path for appending and rotates it once it exceeds maxSizeMB,
+// e.g. as the Writer of a LogTarget. Close it on shutdown to flush the file.
+func OpenLogFile(path string, maxSizeMB int) (io.WriteCloser, error) {
+	return openRotatingFile(path, maxSizeMB)
+}
+
+// NewFileLogger writes FormatLogs lines to path and rotates once the file exceeds maxSizeMB.
+// Close the returned io.Closer on shutdown to flush the file. Requests to skipPaths aren't logged.
+// A file that can't be opened is an error, the caller decides whether that's fatal.
+func NewFileLogger(path string, maxSizeMB int, skipPaths ...string) (gin.HandlerFunc, io.Closer, error) {
+	f, err := openRotatingFile(path, maxSizeMB)
+	if err != nil {
+		return nil, nil, fmt.Errorf("open log file %q: %w", path, err)
+	}
+
+	logger := gin.LoggerWithConfig(gin.LoggerConfig{
+		Formatter: FormatLogs,
+		Output:    f,
+		SkipPaths: skipPaths,
+	})
+	return logger, f, nil
+}
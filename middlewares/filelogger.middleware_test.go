@@ -0,0 +1,43 @@
+package middlewares
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestNewFileLogger(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "access.log")
+	logger, closer, err := NewFileLogger(path, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	r := gin.New()
+	r.GET("/ping", logger, func(ctx *gin.Context) { ctx.Status(http.StatusOK) })
+	r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/ping", nil))
+	if err := closer.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := os.ReadFile(path)
+	if err != nil || !strings.Contains(string(got), "/ping") {
+		t.Errorf("log file %q, %v, want the /ping request in it", got, err)
+	}
+}
+
+// an unusable path is the caller's error to handle, not a panic
+func TestNewFileLoggerOpenError(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "missing-dir", "access.log")
+	logger, closer, err := NewFileLogger(path, 1)
+	if err == nil || logger != nil || closer != nil {
+		t.Fatalf("NewFileLogger(%q) = %v, %v, %v, want only an error", path, logger != nil, closer, err)
+	}
+	if !strings.Contains(err.Error(), path) {
+		t.Errorf("error %q doesn't name the path", err)
+	}
+}
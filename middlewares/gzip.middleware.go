@@ -0,0 +1,154 @@
+package middlewares
+
+import (
+	"compress/gzip"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/gin-gonic/gin"
+)
+
+// content types that are already compressed, gzipping them again just burns CPU
+var compressedTypes = []string{
+	"image/", "video/", "audio/",
+	"application/zip", "application/gzip", "application/x-gzip",
+	"application/x-7z-compressed", "application/x-rar-compressed", "font/woff",
+}
+
+func isCompressedType(contentType string) bool {
+	contentType = strings.ToLower(contentType)
+	for _, t := range compressedTypes {
+		if strings.HasPrefix(contentType, t) {
+			return true
+		}
+	}
+	return false
+}
+
+type gzipWriter struct {
+	gin.ResponseWriter
+	pool     *sync.Pool
+	gz       *gzip.Writer
+	decided  bool
+	compress bool
+}
+
+// decide on the first body write, once the handler has set Content-Type
+func (w *gzipWriter) decide() {
+	if w.decided {
+		return
+	}
+	w.decided = true
+
+	h := w.Header()
+	status := w.Status()
+	if h.Get("Content-Encoding") != "" || isCompressedType(h.Get("Content-Type")) ||
+		strings.HasPrefix(h.Get("Content-Type"), "text/event-stream") || // streams must reach the client as-is
+		status == http.StatusNoContent || status == http.StatusNotModified {
+		return
+	}
+
+	w.compress = true
+	h.Set("Content-Encoding", "gzip")
+	if !strings.Contains(strings.Join(h.Values("Vary"), ","), "Accept-Encoding") {
+		h.Add("Vary", "Accept-Encoding") // handlers.Assets already sets it
+	}
+	h.Del("Content-Length") // the length changes once compressed
+	w.gz = w.pool.Get().(*gzip.Writer)
+	w.gz.Reset(w.ResponseWriter)
+}
+
+func (w *gzipWriter) Write(b []byte) (int, error) {
+	w.decide()
+	if w.compress {
+		return w.gz.Write(b)
+	}
+	return w.ResponseWriter.Write(b)
+}
+
+func (w *gzipWriter) WriteString(s string) (int, error) {
+	return w.Write([]byte(s))
+}
+
+func (w *gzipWriter) Flush() {
+	if w.gz != nil {
+		w.gz.Flush()
+	}
+	w.ResponseWriter.Flush()
+}
+
+// lets http.ResponseController reach the underlying writer (deadlines, flushing)
+func (w *gzipWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+func (w *gzipWriter) close() {
+	if w.gz == nil {
+		return
+	}
+	w.gz.Close()
+	w.gz.Reset(io.Discard)
+	w.pool.Put(w.gz)
+	w.gz = nil
+}
+
+// AcceptsEncoding reports whether coding has a q > 0 in an Accept-Encoding header,
+// e.g. "gzip;q=0" refuses it. Without an entry of its own the "*" entry decides.
+func AcceptsEncoding(header, coding string) bool {
+	q, star := -1.0, -1.0
+	for _, part := range strings.Split(header, ",") {
+		name, params, err := mime.ParseMediaType(strings.TrimSpace(part))
+		if err != nil {
+			continue
+		}
+		v, ok := parseQ(params)
+		if !ok {
+			continue
+		}
+		switch name {
+		case coding:
+			q = v
+		case "*":
+			star = v
+		}
+	}
+	if q >= 0 {
+		return q > 0
+	}
+	return star > 0
+}
+
+//💡 Gzip response compression for clients that send Accept-Encoding: gzip (with a q > 0).
+// level is one of the compress/gzip levels (gzip.DefaultCompression, gzip.BestSpeed ...).
+func Gzip(level int) gin.HandlerFunc {
+	if _, err := gzip.NewWriterLevel(io.Discard, level); err != nil {
+		panic(fmt.Sprintf("⚠️invalid gzip level %d: %v", level, err))
+	}
+	pool := &sync.Pool{
+		New: func() any {
+			gz, _ := gzip.NewWriterLevel(io.Discard, level)
+			return gz
+		},
+	}
+
+	return func(ctx *gin.Context) {
+		if !AcceptsEncoding(ctx.GetHeader("Accept-Encoding"), "gzip") {
+			ctx.Next()
+			return
+		}
+
+		orig := ctx.Writer
+		gw := &gzipWriter{ResponseWriter: orig, pool: pool}
+		ctx.Writer = gw
+		defer func() {
+			gw.close()
+			ctx.Writer = orig
+		}()
+
+		ctx.Next()
+	}
+}
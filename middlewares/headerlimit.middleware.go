@@ -0,0 +1,26 @@
+package middlewares
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+//💡 Cap the number of request header lines (repeated headers count once per value).
+// http.Server.MaxHeaderBytes bounds their total size, this stops thousands of tiny
+// headers that stay under it. Over the cap -> 431.
+func MaxHeaders(n int) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		count := 0
+		for _, values := range ctx.Request.Header {
+			count += len(values)
+		}
+
+		if count > n {
+			abortWithError(ctx, http.StatusRequestHeaderFieldsTooLarge, "too many request headers")
+			return
+		}
+
+		ctx.Next()
+	}
+}
@@ -0,0 +1,53 @@
+package middlewares
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+//💡 HTTPSRedirect sends plain-HTTP requests to the same path on https://host with a 308 (method
+// and body are kept, unlike 301/302). host is the configured canonical host: the client's Host
+// header is never used, it would turn this into an open redirect. Behind a TLS-terminating proxy
+// the scheme comes from proxyHeader ("" = X-Forwarded-Proto), without one from the connection itself.
+// skipPaths (health checks, probes from inside the cluster) are served over HTTP as is.
+func HTTPSRedirect(host, proxyHeader string, skipPaths ...string) gin.HandlerFunc {
+	if host == "" {
+		panic("⚠️HTTPSRedirect: empty canonical host")
+	}
+	if proxyHeader == "" {
+		proxyHeader = "X-Forwarded-Proto"
+	}
+	skip := make(map[string]bool, len(skipPaths))
+	for _, p := range skipPaths {
+		skip[p] = true
+	}
+
+	return func(ctx *gin.Context) {
+		if skip[ctx.Request.URL.Path] || requestScheme(ctx.Request, proxyHeader) == "https" {
+			ctx.Next()
+			return
+		}
+
+		// RequestURI is what the client sent, so a prefix StripPrefix removed is kept
+		uri := ctx.Request.RequestURI
+		if uri == "" {
+			uri = ctx.Request.URL.RequestURI()
+		}
+		ctx.Redirect(http.StatusPermanentRedirect, "https://"+host+uri)
+		ctx.Abort()
+	}
+}
+
+// "https, http" (appended by a proxy chain) -> "https", the first hop is the one the client used
+func requestScheme(r *http.Request, proxyHeader string) string {
+	if proto := r.Header.Get(proxyHeader); proto != "" {
+		first, _, _ := strings.Cut(proto, ",")
+		return strings.ToLower(strings.TrimSpace(first))
+	}
+	if r.TLS != nil {
+		return "https"
+	}
+	return "http"
+}
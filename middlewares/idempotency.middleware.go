@@ -0,0 +1,204 @@
+package middlewares
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	idempotencyHeader = "Idempotency-Key"
+	maxIdempotencyKey = 255
+)
+
+// IdempotentResponse is what gets replayed for a repeated Idempotency-Key
+type IdempotentResponse struct {
+	Status      int
+	Header      http.Header
+	Body        []byte
+	Fingerprint string // of the request that produced it, see requestFingerprint
+}
+
+//💡 IdempotencyStore remembers the first response per key. Reserve returns the stored
+// response if there is one, otherwise claims the key (ok=false: someone else holds it and
+// is still running). Complete stores resp for the key, or with nil releases the claim so a
+// retry runs the handler again.
+type IdempotencyStore interface {
+	Reserve(key string) (stored *IdempotentResponse, ok bool)
+	Complete(key string, resp *IdempotentResponse)
+}
+
+type idempotencyEntry struct {
+	resp    *IdempotentResponse // nil while the first request is in flight
+	created time.Time
+}
+
+// MemoryIdempotencyStore keeps responses in memory for ttl
+type MemoryIdempotencyStore struct {
+	mu        sync.Mutex
+	ttl       time.Duration
+	entries   map[string]idempotencyEntry
+	lastSweep time.Time
+}
+
+func NewMemoryIdempotencyStore(ttl time.Duration) *MemoryIdempotencyStore {
+	return &MemoryIdempotencyStore{ttl: ttl, entries: map[string]idempotencyEntry{}, lastSweep: time.Now()}
+}
+
+func (s *MemoryIdempotencyStore) Reserve(key string) (*IdempotentResponse, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	// keys are rarely reused, so drop expired ones as we go instead of on lookup only
+	if now.Sub(s.lastSweep) > s.ttl {
+		for k, e := range s.entries {
+			if e.resp != nil && now.Sub(e.created) >= s.ttl {
+				delete(s.entries, k)
+			}
+		}
+		s.lastSweep = now
+	}
+
+	e, exists := s.entries[key]
+	switch {
+	case exists && e.resp == nil:
+		return nil, false
+	case exists && now.Sub(e.created) < s.ttl:
+		return e.resp, true
+	}
+	s.entries[key] = idempotencyEntry{created: now}
+	return nil, true
+}
+
+func (s *MemoryIdempotencyStore) Complete(key string, resp *IdempotentResponse) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if resp == nil {
+		delete(s.entries, key)
+		return
+	}
+	s.entries[key] = idempotencyEntry{resp: resp, created: time.Now()}
+}
+
+type idempotencyWriter struct {
+	gin.ResponseWriter
+	before http.Header
+	header http.Header
+	buf    bytes.Buffer
+}
+
+func (w *idempotencyWriter) snapshot() {
+	if w.header == nil {
+		w.header = changedHeaders(w.before, w.ResponseWriter.Header())
+	}
+}
+
+func (w *idempotencyWriter) WriteHeaderNow() {
+	w.snapshot()
+	w.ResponseWriter.WriteHeaderNow()
+}
+
+func (w *idempotencyWriter) Write(b []byte) (int, error) {
+	w.snapshot()
+	w.buf.Write(b)
+	return w.ResponseWriter.Write(b)
+}
+
+func (w *idempotencyWriter) WriteString(s string) (int, error) {
+	return w.Write([]byte(s))
+}
+
+func (w *idempotencyWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+//💡 Idempotency makes POST retries safe: the client sends an Idempotency-Key header (400
+// without one), the first response for it is stored and a retry with the same key gets that
+// response back (Idempotent-Replayed: true) instead of creating the resource twice.
+// Keys are per caller and route. A retry while the first request still runs gets a 409,
+// reusing a key for a different method, path or body a 422. 5xx responses aren't stored
+// so the retry runs the handler again.
+func Idempotency(store IdempotencyStore) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		if ctx.Request.Method != http.MethodPost {
+			ctx.Next()
+			return
+		}
+
+		header := ctx.GetHeader(idempotencyHeader)
+		if header == "" || len(header) > maxIdempotencyKey {
+			abortWithError(ctx, http.StatusBadRequest, "an Idempotency-Key header (1-255 chars) is required")
+			return
+		}
+		// scoped, so one caller's key can't replay another caller's response
+		key := principalOf(ctx) + " " + ctx.FullPath() + " " + header
+		fingerprint, err := requestFingerprint(ctx.Request)
+		if err != nil {
+			abortWithError(ctx, http.StatusBadRequest, "failed to read request body")
+			return
+		}
+
+		stored, ok := store.Reserve(key)
+		if !ok {
+			abortWithError(ctx, http.StatusConflict, "a request with this Idempotency-Key is still being processed")
+			return
+		}
+		if stored != nil && stored.Fingerprint != fingerprint {
+			abortWithError(ctx, http.StatusUnprocessableEntity, "this Idempotency-Key was already used for a different request")
+			return
+		}
+		if stored != nil {
+			h := ctx.Writer.Header()
+			for k, v := range stored.Header {
+				h[k] = append([]string(nil), v...)
+			}
+			h.Set("Idempotent-Replayed", "true")
+			ctx.Status(stored.Status)
+			ctx.Writer.Write(stored.Body)
+			ctx.Abort()
+			return
+		}
+
+		var resp *IdempotentResponse
+		// also runs when the handler panics, so the key isn't stuck "in flight"
+		defer func() { store.Complete(key, resp) }()
+
+		orig := ctx.Writer
+		iw := &idempotencyWriter{ResponseWriter: orig, before: orig.Header().Clone()}
+		ctx.Writer = iw
+		ctx.Next()
+		ctx.Writer = orig
+
+		if orig.Status() >= http.StatusInternalServerError {
+			return
+		}
+		iw.snapshot()
+		resp = &IdempotentResponse{Status: orig.Status(), Header: iw.header, Body: bytes.Clone(iw.buf.Bytes()), Fingerprint: fingerprint}
+	}
+}
+
+// requestFingerprint hashes method, path and body, so a key can't replay the response of a
+// different request. The body is put back for the handler.
+func requestFingerprint(r *http.Request) (string, error) {
+	var body []byte
+	if r.Body != nil {
+		var err error
+		if body, err = io.ReadAll(r.Body); err != nil {
+			return "", err
+		}
+		r.Body = io.NopCloser(bytes.NewReader(body))
+	}
+
+	h := sha256.New()
+	io.WriteString(h, r.Method+" "+r.URL.Path+"\n")
+	h.Write(body)
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
@@ -0,0 +1,65 @@
+package middlewares
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestIdempotencyFingerprint(t *testing.T) {
+	type request struct{ path, body string }
+	first := request{"/items/1", `{"name":"a"}`}
+
+	tests := []struct {
+		name        string
+		retry       request
+		wantStatus  int
+		wantReplay  bool
+		wantHandled int // handler runs over both requests
+	}{
+		{"same request is replayed", first, http.StatusCreated, true, 1},
+		{"different body", request{"/items/1", `{"name":"b"}`}, http.StatusUnprocessableEntity, false, 1},
+		{"different path, same route", request{"/items/2", `{"name":"a"}`}, http.StatusUnprocessableEntity, false, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handled := 0
+			r := gin.New()
+			r.POST("/items/:id", Idempotency(NewMemoryIdempotencyStore(time.Minute)), func(ctx *gin.Context) {
+				handled++
+				body, _ := io.ReadAll(ctx.Request.Body)
+				ctx.String(http.StatusCreated, "%s", body)
+			})
+
+			send := func(rq request) *httptest.ResponseRecorder {
+				req := httptest.NewRequest(http.MethodPost, rq.path, strings.NewReader(rq.body))
+				req.Header.Set("Idempotency-Key", "key-1")
+				w := httptest.NewRecorder()
+				r.ServeHTTP(w, req)
+				return w
+			}
+			if w := send(first); w.Code != http.StatusCreated || w.Body.String() != first.body {
+				t.Fatalf("first request: %d %s, want 201 with the body echoed", w.Code, w.Body)
+			}
+			w := send(tt.retry)
+
+			if w.Code != tt.wantStatus {
+				t.Errorf("retry status = %d, want %d (body %s)", w.Code, tt.wantStatus, w.Body)
+			}
+			if replayed := w.Header().Get("Idempotent-Replayed") == "true"; replayed != tt.wantReplay {
+				t.Errorf("replayed = %v, want %v", replayed, tt.wantReplay)
+			}
+			if tt.wantReplay && w.Body.String() != first.body {
+				t.Errorf("replayed body %q, want %q", w.Body, first.body)
+			}
+			if handled != tt.wantHandled {
+				t.Errorf("handler ran %d times, want %d", handled, tt.wantHandled)
+			}
+		})
+	}
+}
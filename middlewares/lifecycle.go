@@ -0,0 +1,44 @@
+package middlewares
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+//💡 LogLifecycle prints one JSON line for a server lifecycle event (startup, route, shutdown ...)
+// to gin.DefaultWriter, with the same "timestamp" / "latency_ms" / "latency" keys as FormatLogsJSON
+// so request and lifecycle lines can go through the same log pipeline.
+func LogLifecycle(event string, fields gin.H) {
+	entry := gin.H{
+		"timestamp": time.Now(),
+		"event":     event,
+	}
+	for k, v := range fields {
+		if d, ok := v.(time.Duration); ok && k == "latency" {
+			entry["latency_ms"] = durationMS(d)
+			v = d.String()
+		}
+		entry[k] = v
+	}
+
+	j, err := json.Marshal(entry)
+	if err != nil {
+		fmt.Fprintln(gin.DefaultErrorWriter, "⚠️failed to marshal! ---", err)
+		return
+	}
+	fmt.Fprintln(gin.DefaultWriter, string(j))
+}
+
+// LogRoutes prints the route table, one "route" line per registered method + path
+func LogRoutes(routes gin.RoutesInfo) {
+	for _, r := range routes {
+		LogLifecycle("route", gin.H{
+			"method":  r.Method,
+			"path":    r.Path,
+			"handler": r.Handler,
+		})
+	}
+}
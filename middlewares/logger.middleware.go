@@ -3,59 +3,140 @@ package middlewares
 import (
 	"encoding/json"
 	"fmt"
+	"os"
+	"strconv"
 	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/skyy/gin-gonic/ctxkeys"
 )
 
 // logger mw
 
+//💡 Paths the access log ignores (probes & scrapes hit these every few seconds, browsers the favicon)
+var DefaultSkipPaths = []string{"/healthz", "/readyz", "/metrics", "/favicon.ico"}
+
+// Logger wires a formatter (FormatLogs / FormatLogsJSON) into gin's logger,
+// skipping skipPaths entirely via gin.LoggerConfig.SkipPaths.
+func Logger(formatter gin.LogFormatter, skipPaths ...string) gin.HandlerFunc {
+	return gin.LoggerWithConfig(gin.LoggerConfig{
+		Formatter: formatter,
+		SkipPaths: skipPaths,
+	})
+}
+
 //💡 Write logs to files in GIN.
+// Method & status are colorized when the output is a terminal (see useColor).
 func FormatLogs(param gin.LogFormatterParams)string{
-	return fmt.Sprintf("{%s - [%s] \"%s %s %s %d %s \"%s\" %s\"} \n",
+	param = RedactParams(param, RedactKeys)
+
+	method, status := param.Method, strconv.Itoa(param.StatusCode)
+	if useColor(param) {
+		reset := param.ResetColor()
+		method = param.MethodColor() + method + reset
+		status = statusColor(param.StatusCode) + status + reset
+	}
+
+	return fmt.Sprintf("{%s - [%s] \"%s %s %s %s %s \"%s\" %s\"} \n",
 	param.ClientIP,
 	param.TimeStamp.Format(time.RFC1123),
-	param.Method,
-	param.Path,
+	method,
+	sanitizeLogField(param.Path),
 	param.Request.Proto,
-	param.StatusCode,
+	status,
 	param.Latency,
-	param.Request.UserAgent(),
-	param.ErrorMessage,
+	sanitizeLogField(param.Request.UserAgent()),
+	sanitizeLogField(param.ErrorMessage),
 )
 }
 
+// sanitizeLogField escapes CR/LF, other control chars, quotes and backslashes the way Go
+// string literals do ("a\nb" instead of a line break), so a crafted path or user agent
+// can't end the line and forge an entry. The JSON formatter gets this from encoding/json.
+func sanitizeLogField(s string) string {
+	q := strconv.Quote(s)
+	return q[1 : len(q)-1]
+}
+
+// gin only reports color output for terminals; NO_COLOR (https://no-color.org)
+// and a "no_color" context key turn it off again.
+func useColor(param gin.LogFormatterParams) bool {
+	if !param.IsOutputColor() || os.Getenv("NO_COLOR") != "" {
+		return false
+	}
+	_, noColor := param.Keys["no_color"]
+	return !noColor
+}
+
+// green 2xx, yellow 3xx/4xx, red 5xx
+func statusColor(code int) string {
+	switch {
+	case code >= 500:
+		return "\033[97;41m"
+	case code >= 300:
+		return "\033[90;43m"
+	default:
+		return "\033[97;42m"
+	}
+}
+
 //💡 Logging in JSON format in GIN. (Real world situation).
+// json tags keep field names stable for log aggregators (Elasticsearch, Loki...)
 type logFormatLocal struct{
-	TimeStamp time.Time
-	StatusCode int
-	ClientIP string
-	Method string
-	Path string
-	Latency time.Duration
-	RequestProto string
-	ErrorMessage string
+	TimeStamp time.Time `json:"timestamp"`
+	StatusCode int `json:"status"`
+	ClientIP string `json:"client_ip"`
+	Method string `json:"method"`
+	Path string `json:"path"`
+	Route string `json:"route,omitempty"` // route template (see CaptureRoute), group by this rather than path
+	LatencyMS float64 `json:"latency_ms"` // for dashboards to graph
+	LatencyHuman string `json:"latency"` // for humans reading raw logs, e.g. "12.3ms"
+	RequestProto string `json:"proto"`
+	ErrorMessage string `json:"error"`
+	RequestID string `json:"request_id,omitempty"`
+	TenantID string `json:"tenant_id,omitempty"` // only with TenantResolver
+	RequestBody string `json:"request_body,omitempty"` // only with BodyLogger
+	ResponseBody string `json:"response_body,omitempty"`
+	Stack string `json:"stack,omitempty"` // only set by RecoveryJSON
 }
 
 
 func FormatLogsJSON(param gin.LogFormatterParams)string{
+	param = RedactParams(param, RedactKeys)
+
+	// some error paths format before gin filled in the timing, don't log 0001-01-01 / negative latencies
+	if param.TimeStamp.IsZero() {
+		param.TimeStamp = time.Now()
+	}
+	if param.Latency < 0 {
+		param.Latency = 0
+	}
+
 	params:= &logFormatLocal{
 	TimeStamp: param.TimeStamp,
 	StatusCode: param.StatusCode,
 	ClientIP: 	param.ClientIP,
 	Method: param.Method,
 	Path: param.Path,
-	Latency: param.Latency,
+	LatencyMS: durationMS(param.Latency),
+	LatencyHuman: param.Latency.String(),
 	RequestProto: param.Request.Proto,
 	ErrorMessage: 	param.ErrorMessage,
 	}
+	params.RequestID = ctxkeys.RequestIDFrom(param.Keys)
+	params.Route = ctxkeys.RouteFrom(param.Keys)
+	params.TenantID = ctxkeys.TenantFrom(param.Keys)
+	params.RequestBody = ctxkeys.RequestBodyFrom(param.Keys)
+	params.ResponseBody = ctxkeys.ResponseBodyFrom(param.Keys)
 
+	// Gin's writer emits whatever we return, so no printing here (one line per request)
 	j,err:=json.Marshal(params)
 	if err != nil {
-		fmt.Println("⚠️failed to marshal! ---", err)
-		return err.Error()
+		return fmt.Sprintf("⚠️failed to marshal! --- %v\n", err)
 	}
-	fmt.Println(string(j))
-	return  string(j)
-	
-}
\ No newline at end of file
+	return  string(j) + "\n"
+}
+
+func durationMS(d time.Duration) float64 {
+	return float64(d) / float64(time.Millisecond)
+}
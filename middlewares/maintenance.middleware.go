@@ -0,0 +1,38 @@
+package middlewares
+
+import (
+	"math"
+	"net/http"
+	"strconv"
+	"sync/atomic"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+//💡 MaintenanceMode makes the service read-only while flag is set (e.g. during a migration):
+// POST/PUT/PATCH/DELETE get a 503 with Retry-After, reads keep working.
+// skipPaths (route templates) stay writable, e.g. the endpoint that turns maintenance off again.
+func MaintenanceMode(flag *atomic.Bool, retryAfter time.Duration, skipPaths ...string) gin.HandlerFunc {
+	skip := make(map[string]bool, len(skipPaths))
+	for _, p := range skipPaths {
+		skip[p] = true
+	}
+	retry := strconv.Itoa(max(1, int(math.Ceil(retryAfter.Seconds()))))
+
+	return func(ctx *gin.Context) {
+		if !flag.Load() || skip[ctx.FullPath()] {
+			ctx.Next()
+			return
+		}
+
+		switch ctx.Request.Method {
+		case http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete:
+			ctx.Header("Retry-After", retry)
+			abortWithError(ctx, http.StatusServiceUnavailable, "down for maintenance, read-only until it's over")
+			return
+		}
+
+		ctx.Next()
+	}
+}
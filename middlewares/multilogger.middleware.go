@@ -0,0 +1,37 @@
+package middlewares
+
+import (
+	"io"
+
+	"github.com/gin-gonic/gin"
+)
+
+// LogTarget is one access log output: where it goes and how lines look.
+// A nil Writer is gin.DefaultWriter (stdout), a nil Formatter gin's default format.
+type LogTarget struct {
+	Writer    io.Writer
+	Formatter gin.LogFormatter // FormatLogs, FormatLogsJSON ...
+	SkipPaths []string
+}
+
+//💡 MultiLogger logs every request once per target, e.g. readable FormatLogs on the console
+// and FormatLogsJSON to a file:
+//
+//	router.Use(middlewares.MultiLogger(
+//		middlewares.LogTarget{Writer: os.Stdout, Formatter: middlewares.FormatLogs},
+//		middlewares.LogTarget{Writer: logFile, Formatter: middlewares.FormatLogsJSON},
+//	)...)
+//
+// Like Chain it returns a gin.HandlersChain: each target is its own gin logger (so colors
+// are decided per writer), and loggers wrap ctx.Next, so they can't share one HandlerFunc.
+func MultiLogger(targets ...LogTarget) gin.HandlersChain {
+	chain := make(gin.HandlersChain, 0, len(targets))
+	for _, t := range targets {
+		chain = append(chain, gin.LoggerWithConfig(gin.LoggerConfig{
+			Formatter: t.Formatter,
+			Output:    t.Writer,
+			SkipPaths: t.SkipPaths,
+		}))
+	}
+	return chain
+}
@@ -0,0 +1,53 @@
+package middlewares
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+)
+
+//💡 StripPrefix removes prefix (e.g. "/api/v1", added by the gateway) from the path before
+// gin routes the request, so every existing route answers under it. Paths without the
+// prefix get a 404. It wraps the whole engine rather than being a gin middleware because
+// gin has already picked the route by the time router.Use handlers run.
+//
+//	server.Handler = middlewares.StripPrefix("/api/v1", router)
+func StripPrefix(prefix string, next http.Handler) http.Handler {
+	prefix = "/" + strings.Trim(prefix, "/")
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		rest, ok := cutPathPrefix(r.URL.Path, prefix)
+		if !ok {
+			w.Header().Set("Content-Type", "application/json; charset=utf-8")
+			w.WriteHeader(http.StatusNotFound)
+			json.NewEncoder(w).Encode(map[string]any{
+				"code":    http.StatusNotFound,
+				"message": "route " + r.URL.Path + " not found",
+			})
+			return
+		}
+
+		r2 := r.Clone(r.Context())
+		r2.URL.Path = rest
+		if r.URL.RawPath != "" {
+			if rawRest, ok := cutPathPrefix(r.URL.RawPath, prefix); ok {
+				r2.URL.RawPath = rawRest
+			} else {
+				r2.URL.RawPath = ""
+			}
+		}
+		next.ServeHTTP(w, r2)
+	})
+}
+
+// "/api/v1/users" -> "/users", "/api/v1" -> "/", but "/api/v10" isn't under "/api/v1"
+func cutPathPrefix(path, prefix string) (string, bool) {
+	rest, ok := strings.CutPrefix(path, prefix)
+	if !ok || (rest != "" && rest[0] != '/') {
+		return "", false
+	}
+	if rest == "" {
+		rest = "/"
+	}
+	return rest, true
+}
@@ -0,0 +1,76 @@
+package middlewares
+
+import (
+	"math"
+	"net/http"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"golang.org/x/time/rate"
+)
+
+type clientLimiter struct {
+	limiter  *rate.Limiter
+	lastSeen atomic.Int64 // unix nano
+}
+
+//💡 Per-client (ClientIP) token bucket rate limiter.
+// Limiters idle for longer than ttl (default 10m) are evicted so memory stays bounded.
+// Call the returned stop func on shutdown to end the eviction goroutine (safe to call twice).
+func RateLimit(rps int, burst int, ttl ...time.Duration) (gin.HandlerFunc, func()) {
+	idle := 10 * time.Minute
+	if len(ttl) > 0 && ttl[0] > 0 {
+		idle = ttl[0]
+	}
+
+	var clients sync.Map // ip -> *clientLimiter
+
+	done := make(chan struct{})
+	go func() {
+		ticker := time.NewTicker(idle)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+			}
+			cutoff := time.Now().Add(-idle).UnixNano()
+			clients.Range(func(key, value any) bool {
+				if value.(*clientLimiter).lastSeen.Load() < cutoff {
+					clients.Delete(key)
+				}
+				return true
+			})
+		}
+	}()
+
+	handler := func(ctx *gin.Context) {
+		v, ok := clients.Load(ctx.ClientIP())
+		if !ok {
+			v, _ = clients.LoadOrStore(ctx.ClientIP(), &clientLimiter{
+				limiter: rate.NewLimiter(rate.Limit(rps), burst),
+			})
+		}
+		cl := v.(*clientLimiter)
+		cl.lastSeen.Store(time.Now().UnixNano())
+
+		r := cl.limiter.Reserve()
+		if delay := r.Delay(); !r.OK() || delay > 0 {
+			r.Cancel()
+			retryAfter := 1
+			if r.OK() {
+				retryAfter = max(1, int(math.Ceil(delay.Seconds())))
+			}
+			ctx.Header("Retry-After", strconv.Itoa(retryAfter))
+			abortWithError(ctx, http.StatusTooManyRequests, "too many requests, slow down")
+			return
+		}
+
+		ctx.Next()
+	}
+	return handler, sync.OnceFunc(func() { close(done) })
+}
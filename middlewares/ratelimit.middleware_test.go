@@ -0,0 +1,45 @@
+package middlewares
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// waitGoroutines waits for the goroutine count to drop back to want, false if it never does
+func waitGoroutines(want int) bool {
+	for deadline := time.Now().Add(time.Second); time.Now().Before(deadline); time.Sleep(time.Millisecond) {
+		if runtime.NumGoroutine() <= want {
+			return true
+		}
+	}
+	return false
+}
+
+func TestRateLimitStop(t *testing.T) {
+	before := runtime.NumGoroutine()
+	limit, stop := RateLimit(1, 1, time.Millisecond)
+
+	stop()
+	stop() // a second call is harmless
+	if !waitGoroutines(before) {
+		t.Errorf("%d goroutines after stop, want %d: the eviction goroutine is still running", runtime.NumGoroutine(), before)
+	}
+
+	// only eviction stops, limiting goes on
+	r := gin.New()
+	r.GET("/", limit, func(ctx *gin.Context) { ctx.Status(http.StatusOK) })
+	var codes []int
+	for range 2 {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+		codes = append(codes, w.Code)
+	}
+	if codes[0] != http.StatusOK || codes[1] != http.StatusTooManyRequests {
+		t.Errorf("statuses after stop %v, want [200 429]", codes)
+	}
+}
@@ -0,0 +1,63 @@
+package middlewares
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"runtime/debug"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/skyy/gin-gonic/ctxkeys"
+)
+
+//💡 Recovery mw that logs panics in the same JSON shape as FormatLogsJSON.
+// The client only ever gets a generic 500, never the panic message.
+// Use it after the access loggers & metrics: they don't defer around ctx.Next(),
+// so a panic unwinding past them would leave the request unlogged and uncounted.
+func RecoveryJSON() gin.HandlerFunc {
+	return RecoveryWithHandler(nil)
+}
+
+//💡 RecoveryWithHandler is RecoveryJSON plus a callback (e.g. report to Sentry).
+// fn gets the recovered value after the stack is logged but before the 500 is
+// written, so it can still enrich the context / response headers.
+func RecoveryWithHandler(fn func(ctx *gin.Context, err any)) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		start := time.Now()
+		defer func() {
+			rec := recover()
+			if rec == nil {
+				return
+			}
+
+			latency := time.Since(start)
+			entry := &logFormatLocal{
+				TimeStamp:    time.Now(),
+				StatusCode:   http.StatusInternalServerError,
+				ClientIP:     ctx.ClientIP(),
+				Method:       ctx.Request.Method,
+				Path:         ctx.Request.URL.Path,
+				LatencyMS:    durationMS(latency),
+				LatencyHuman: latency.String(),
+				RequestProto: ctx.Request.Proto,
+				ErrorMessage: fmt.Sprint(rec),
+				RequestID:    ctxkeys.GetRequestID(ctx),
+				Stack:        string(debug.Stack()),
+			}
+			if j, err := json.Marshal(entry); err == nil {
+				fmt.Fprintln(gin.DefaultErrorWriter, string(j))
+			} else {
+				fmt.Fprintln(gin.DefaultErrorWriter, "⚠️failed to marshal! ---", err)
+			}
+
+			if fn != nil {
+				fn(ctx, rec)
+			}
+
+			abortWithError(ctx, http.StatusInternalServerError, "internal server error")
+		}()
+
+		ctx.Next()
+	}
+}
@@ -0,0 +1,79 @@
+package middlewares
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestRecoveryWithHandler(t *testing.T) {
+	var logged bytes.Buffer
+	prev := gin.DefaultErrorWriter
+	gin.DefaultErrorWriter = &logged
+	t.Cleanup(func() { gin.DefaultErrorWriter = prev })
+
+	var (
+		calls      int
+		gotPath    string
+		gotValue   any
+		wasWritten bool
+	)
+	r := gin.New()
+	r.Use(RecoveryWithHandler(func(ctx *gin.Context, err any) {
+		calls++
+		gotPath = ctx.Request.URL.Path
+		gotValue = err
+		wasWritten = ctx.Writer.Written()
+		ctx.Header("X-Error-ID", "evt-1") // enriching the response has to still be possible
+	}))
+	r.GET("/boom", func(*gin.Context) { panic("kaboom") })
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/boom", nil))
+
+	if calls != 1 {
+		t.Fatalf("callback ran %d times, want 1", calls)
+	}
+	if gotValue != "kaboom" {
+		t.Errorf("callback got %v, want the recovered value %q", gotValue, "kaboom")
+	}
+	if gotPath != "/boom" {
+		t.Errorf("callback got the context of %q, want /boom", gotPath)
+	}
+	if wasWritten {
+		t.Error("response was already written when the callback ran")
+	}
+	if w.Code != http.StatusInternalServerError {
+		t.Errorf("status = %d, want 500", w.Code)
+	}
+	if got := w.Header().Get("X-Error-ID"); got != "evt-1" {
+		t.Errorf("X-Error-ID = %q, want the header set by the callback", got)
+	}
+	if strings.Contains(w.Body.String(), "kaboom") {
+		t.Errorf("panic message leaked to the client: %s", w.Body)
+	}
+	if !strings.Contains(logged.String(), "kaboom") || !strings.Contains(logged.String(), `"stack"`) {
+		t.Errorf("panic wasn't logged with its stack: %s", logged.String())
+	}
+}
+
+func TestRecoveryWithHandlerNoPanic(t *testing.T) {
+	called := false
+	r := gin.New()
+	r.Use(RecoveryWithHandler(func(*gin.Context, any) { called = true }))
+	r.GET("/", func(ctx *gin.Context) { ctx.Status(http.StatusNoContent) })
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	if called {
+		t.Error("callback ran without a panic")
+	}
+	if w.Code != http.StatusNoContent {
+		t.Errorf("status = %d, want 204", w.Code)
+	}
+}
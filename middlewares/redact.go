@@ -0,0 +1,111 @@
+package middlewares
+
+import (
+	"bytes"
+	"encoding/json"
+	"mime"
+	"net/url"
+	"slices"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+const redacted = "***"
+
+//💡 Headers / query params / JSON fields that never reach the logs (matched case-insensitively).
+// Token is the header Authenticate reads (and the field /login answers with).
+var RedactKeys = []string{"Authorization", "Token", "password", "api_key", "access_token", "refresh_token"}
+
+// RedactParams returns a copy of param with every header and query param named in keys
+// replaced by "***". The original request is left untouched.
+func RedactParams(param gin.LogFormatterParams, keys []string) gin.LogFormatterParams {
+	if len(keys) == 0 {
+		return param
+	}
+
+	if path, query, ok := strings.Cut(param.Path, "?"); ok {
+		param.Path = path + "?" + redactQuery(query, keys)
+	}
+
+	if param.Request != nil {
+		r := *param.Request
+		r.Header = param.Request.Header.Clone()
+		for _, k := range keys {
+			if r.Header.Get(k) != "" {
+				r.Header.Set(k, redacted)
+			}
+		}
+		if r.URL != nil && r.URL.RawQuery != "" {
+			u := *r.URL
+			u.RawQuery = redactQuery(u.RawQuery, keys)
+			r.URL = &u
+		}
+		param.Request = &r
+	}
+
+	return param
+}
+
+// keeps the raw encoding of everything except the redacted values
+func redactQuery(query string, keys []string) string {
+	pairs := strings.Split(query, "&")
+	for i, pair := range pairs {
+		k, _, _ := strings.Cut(pair, "=")
+		name, err := url.QueryUnescape(k)
+		if err != nil {
+			name = k
+		}
+		for _, key := range keys {
+			if strings.EqualFold(name, key) {
+				pairs[i] = k + "=" + redacted
+				break
+			}
+		}
+	}
+	return strings.Join(pairs, "&")
+}
+
+// redactBody replaces the values of keys in a JSON or form body, at any depth for JSON.
+// A JSON body that doesn't parse (e.g. cut off at BodyLogger's cap) may still hold a
+// secret, so it is dropped as a whole. Other content types are returned unchanged.
+func redactBody(contentType, body string, keys []string) string {
+	mt, _, _ := mime.ParseMediaType(contentType)
+	switch mt {
+	case "application/x-www-form-urlencoded":
+		return redactQuery(body, keys)
+	case "application/json":
+		dec := json.NewDecoder(strings.NewReader(body))
+		dec.UseNumber() // keep big numbers as they were sent
+		var v any
+		if err := dec.Decode(&v); err != nil || dec.More() {
+			return redacted
+		}
+		var buf bytes.Buffer
+		enc := json.NewEncoder(&buf)
+		enc.SetEscapeHTML(false)
+		if err := enc.Encode(redactJSON(v, keys)); err != nil {
+			return redacted
+		}
+		return strings.TrimSuffix(buf.String(), "\n")
+	}
+	return body
+}
+
+func redactJSON(v any, keys []string) any {
+	switch v := v.(type) {
+	case map[string]any:
+		for k, val := range v {
+			if slices.ContainsFunc(keys, func(key string) bool { return strings.EqualFold(k, key) }) {
+				v[k] = redacted
+				continue
+			}
+			v[k] = redactJSON(val, keys)
+		}
+	case []any:
+		for i, val := range v {
+			v[i] = redactJSON(val, keys)
+		}
+	}
+	return v
+}
@@ -0,0 +1,66 @@
+package middlewares
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+)
+
+// how long a request may wait on Redis before we give up and let it through
+const redisLimitTimeout = 50 * time.Millisecond
+
+// Sliding window log in a sorted set: drop entries older than the window, admit if fewer than
+// limit remain. Redis' own clock is used so replicas with skewed clocks agree.
+// Returns {allowed, ms until the oldest entry leaves the window}.
+var slidingWindow = redis.NewScript(`
+local t = redis.call('TIME')
+local now = tonumber(t[1]) * 1000 + math.floor(tonumber(t[2]) / 1000)
+local window = tonumber(ARGV[1])
+local limit = tonumber(ARGV[2])
+
+redis.call('ZREMRANGEBYSCORE', KEYS[1], 0, now - window)
+if redis.call('ZCARD', KEYS[1]) < limit then
+	redis.call('ZADD', KEYS[1], now, ARGV[3])
+	redis.call('PEXPIRE', KEYS[1], window)
+	return {1, 0}
+end
+local oldest = redis.call('ZRANGE', KEYS[1], 0, 0, 'WITHSCORES')
+return {0, tonumber(oldest[2]) + window - now}
+`)
+
+//💡 RedisRateLimit allows limit requests per window per client, counted in Redis so every
+// replica shares the same budget (RateLimit only sees its own traffic). Clients are keyed by
+// ClientIP: this runs before auth, so an unverified header would let a client pick a fresh
+// bucket per request. When Redis is down or slow, requests are let through (fail open) and
+// the error is logged: an outage shouldn't take the API with it.
+func RedisRateLimit(client *redis.Client, limit int, window time.Duration) gin.HandlerFunc {
+	windowMS := window.Milliseconds()
+
+	return func(ctx *gin.Context) {
+		rctx, cancel := context.WithTimeout(ctx.Request.Context(), redisLimitTimeout)
+		defer cancel()
+
+		res, err := slidingWindow.Run(rctx, client, []string{"ratelimit:ip:" + ctx.ClientIP()}, windowMS, limit, uuid.NewString()).Int64Slice()
+		if err != nil || len(res) != 2 {
+			fmt.Fprintln(gin.DefaultErrorWriter, "⚠️redis rate limit unavailable, letting the request through ---", err)
+			ctx.Next()
+			return
+		}
+
+		if res[0] == 0 {
+			retryAfter := max(1, int(math.Ceil(float64(res[1])/1000)))
+			ctx.Header("Retry-After", strconv.Itoa(retryAfter))
+			abortWithError(ctx, http.StatusTooManyRequests, "too many requests, slow down")
+			return
+		}
+
+		ctx.Next()
+	}
+}
@@ -0,0 +1,31 @@
+package middlewares
+
+import (
+	"regexp"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/skyy/gin-gonic/ctxkeys"
+)
+
+const RequestIDHeader = "X-Request-ID"
+
+// what a caller's request ID may look like (UUIDs, ULIDs, trace IDs ...): it ends up in
+// every log line and is echoed back, so no spaces, quotes or control characters
+var validRequestID = regexp.MustCompile(`^[A-Za-z0-9._:-]{1,128}$`)
+
+//💡 Request ID mw: reuse the caller's X-Request-ID or generate one,
+// keep it on the context (ctxkeys.GetRequestID) and echo it back so logs can be correlated.
+// IDs longer than 128 chars or with anything but letters, digits and . _ : - are replaced.
+func RequestID() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		id := ctx.GetHeader(RequestIDHeader)
+		if !validRequestID.MatchString(id) {
+			id = uuid.NewString()
+		}
+
+		ctxkeys.SetRequestID(ctx, id)
+		ctx.Header(RequestIDHeader, id)
+		ctx.Next()
+	}
+}
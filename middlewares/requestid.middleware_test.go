@@ -0,0 +1,60 @@
+package middlewares
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/skyy/gin-gonic/ctxkeys"
+)
+
+func TestRequestID(t *testing.T) {
+	tests := []struct {
+		name   string
+		header string
+		reused bool
+	}{
+		{"uuid", "3f2b8c1e-0c5e-4a57-9d3a-6f1f0c7a9b2e", true},
+		{"trace id", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01", true},
+		{"128 chars", strings.Repeat("a", 128), true},
+		{"missing", "", false},
+		{"129 chars", strings.Repeat("a", 129), false},
+		{"spaces", "id with spaces", false},
+		{"log injection", "x\" level=error msg=\"forged", false},
+		{"control characters", "abc\x1b[31m", false},
+		{"non-ASCII", "idé", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var onCtx string
+			r := gin.New()
+			r.GET("/", RequestID(), func(ctx *gin.Context) {
+				onCtx = ctxkeys.GetRequestID(ctx)
+				ctx.Status(http.StatusOK)
+			})
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			if tt.header != "" {
+				req.Header.Set(RequestIDHeader, tt.header)
+			}
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			echoed := w.Header().Get(RequestIDHeader)
+			if echoed != onCtx {
+				t.Errorf("echoed %q, context has %q", echoed, onCtx)
+			}
+			if tt.reused {
+				if echoed != tt.header {
+					t.Errorf("id = %q, want the caller's %q", echoed, tt.header)
+				}
+				return
+			}
+			if _, err := uuid.Parse(echoed); err != nil {
+				t.Errorf("id = %q, want a generated UUID", echoed)
+			}
+		})
+	}
+}
@@ -0,0 +1,29 @@
+package middlewares
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/skyy/gin-gonic/auth/jwt"
+)
+
+//💡 Route-level authorization, runs after jwt.JWTAuth has put the claims on the context.
+// The user needs at least one of roles (from the "role" or "roles" claim), otherwise 403.
+func RequireRole(roles ...string) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		claims, ok := jwt.GetClaims(ctx)
+		if !ok {
+			abortWithError(ctx, http.StatusForbidden, "no authenticated user")
+			return
+		}
+
+		for _, role := range roles {
+			if claims.HasRole(role) {
+				ctx.Next()
+				return
+			}
+		}
+
+		abortWithError(ctx, http.StatusForbidden, "insufficient role")
+	}
+}
@@ -0,0 +1,64 @@
+package middlewares
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/skyy/gin-gonic/auth/jwt"
+)
+
+func TestRequireRole(t *testing.T) {
+	secret := []byte("test-secret")
+
+	tests := []struct {
+		name     string
+		claims   jwt.Claims
+		required []string
+		want     int
+	}{
+		{"no role", jwt.Claims{Subject: "u"}, []string{"admin"}, http.StatusForbidden},
+		{"one matching role claim", jwt.Claims{Subject: "u", Role: "admin"}, []string{"admin"}, http.StatusOK},
+		{"one non-matching role claim", jwt.Claims{Subject: "u", Role: "editor"}, []string{"admin"}, http.StatusForbidden},
+		{"matching entry in roles", jwt.Claims{Subject: "u", Roles: []string{"editor", "admin"}}, []string{"admin"}, http.StatusOK},
+		{"no matching entry in roles", jwt.Claims{Subject: "u", Roles: []string{"editor", "viewer"}}, []string{"admin"}, http.StatusForbidden},
+		{"any of several required", jwt.Claims{Subject: "u", Roles: []string{"ops"}}, []string{"admin", "ops"}, http.StatusOK},
+		{"none of several required", jwt.Claims{Subject: "u", Role: "viewer"}, []string{"admin", "ops"}, http.StatusForbidden},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			token, err := jwt.IssueToken(tt.claims, secret, time.Minute)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			r := gin.New()
+			r.GET("/", jwt.JWTAuth(secret), RequireRole(tt.required...), func(ctx *gin.Context) {
+				ctx.Status(http.StatusOK)
+			})
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			req.Header.Set("Authorization", "Bearer "+token)
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			if w.Code != tt.want {
+				t.Errorf("status = %d, want %d (body %s)", w.Code, tt.want, w.Body)
+			}
+		})
+	}
+}
+
+func TestRequireRoleWithoutClaims(t *testing.T) {
+	r := gin.New()
+	r.GET("/", RequireRole("admin"), func(ctx *gin.Context) {
+		t.Error("handler ran without authenticated claims")
+	})
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	if w.Code != http.StatusForbidden {
+		t.Errorf("status = %d, want 403", w.Code)
+	}
+}
@@ -0,0 +1,24 @@
+package middlewares
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/skyy/gin-gonic/ctxkeys"
+)
+
+//💡 RouteTemplate is the matched route, e.g. /admin/get-UrlParams/:name/:age instead of
+// /admin/get-UrlParams/Alice/30, so logs & metrics group by endpoint.
+// Requests that matched nothing (404s) all share "unmatched".
+func RouteTemplate(ctx *gin.Context) string {
+	if route := ctx.FullPath(); route != "" {
+		return route
+	}
+	return "unmatched"
+}
+
+// CaptureRoute keeps RouteTemplate on the context (ctxkeys.GetRoute), the log formatters only get ctx.Keys
+func CaptureRoute() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		ctxkeys.SetRoute(ctx, RouteTemplate(ctx))
+		ctx.Next()
+	}
+}
@@ -0,0 +1,89 @@
+package middlewares
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/santhosh-tekuri/jsonschema/v6"
+)
+
+// SchemaViolation is one failed rule, Field is a JSON pointer into the body (e.g. "/age")
+type SchemaViolation struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+//💡 ValidateSchema checks JSON bodies against the JSON Schema file at schemaPath, so the
+// contract can live in schemas/*.json instead of struct tags. The schema is compiled once,
+// a bad schema panics at startup. Violations abort with 422 listing every one of them.
+// The body is put back afterwards, so the handler can still bind it.
+func ValidateSchema(schemaPath string) gin.HandlerFunc {
+	schema, err := jsonschema.NewCompiler().Compile(schemaPath)
+	if err != nil {
+		panic(fmt.Sprintf("⚠️failed to load JSON schema %q: %v", schemaPath, err))
+	}
+
+	return func(ctx *gin.Context) {
+		if ctx.Request.Body == nil {
+			abortSchema(ctx, http.StatusBadRequest, "request body is required", nil)
+			return
+		}
+		body, err := io.ReadAll(ctx.Request.Body)
+		if err != nil {
+			var maxErr *http.MaxBytesError
+			if errors.As(err, &maxErr) {
+				abortSchema(ctx, http.StatusRequestEntityTooLarge, "request body too large", nil)
+				return
+			}
+			abortSchema(ctx, http.StatusBadRequest, "failed to read request body", nil)
+			return
+		}
+		ctx.Request.Body = io.NopCloser(bytes.NewReader(body))
+
+		doc, err := jsonschema.UnmarshalJSON(bytes.NewReader(body))
+		if err != nil {
+			abortSchema(ctx, http.StatusBadRequest, "request body is not valid JSON", nil)
+			return
+		}
+
+		if err := schema.Validate(doc); err != nil {
+			var verr *jsonschema.ValidationError
+			if !errors.As(err, &verr) {
+				abortSchema(ctx, http.StatusInternalServerError, "schema validation failed", nil)
+				return
+			}
+			abortSchema(ctx, http.StatusUnprocessableEntity, "request body does not match the schema", schemaViolations(verr))
+			return
+		}
+
+		ctx.Next()
+	}
+}
+
+// flattens the error tree into one entry per failed keyword
+func schemaViolations(err *jsonschema.ValidationError) []SchemaViolation {
+	var out []SchemaViolation
+	for _, unit := range err.BasicOutput().Errors {
+		if unit.Error == nil {
+			continue
+		}
+		field := unit.InstanceLocation
+		if field == "" {
+			field = "/"
+		}
+		out = append(out, SchemaViolation{Field: field, Message: unit.Error.String()})
+	}
+	return out
+}
+
+func abortSchema(ctx *gin.Context, code int, msg string, details []SchemaViolation) {
+	body := errorBody(code, msg)
+	if len(details) > 0 {
+		body["details"] = details
+	}
+	ctx.AbortWithStatusJSON(code, body)
+}
@@ -0,0 +1,67 @@
+package middlewares
+
+import (
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// timingWriter stamps the timing headers right before the headers go out,
+// since they can't be added once the response has started.
+type timingWriter struct {
+	gin.ResponseWriter
+	start time.Time
+	once  sync.Once
+}
+
+func (w *timingWriter) stamp() {
+	w.once.Do(func() {
+		ms := float64(time.Since(w.start)) / float64(time.Millisecond)
+		w.Header().Set("Server-Timing", fmt.Sprintf("total;dur=%.2f", ms))
+		w.Header().Set("X-Response-Time", fmt.Sprintf("%.2fms", ms))
+	})
+}
+
+func (w *timingWriter) WriteHeaderNow() {
+	w.stamp()
+	w.ResponseWriter.WriteHeaderNow()
+}
+
+func (w *timingWriter) Write(b []byte) (int, error) {
+	w.stamp()
+	return w.ResponseWriter.Write(b)
+}
+
+func (w *timingWriter) WriteString(s string) (int, error) {
+	w.stamp()
+	return w.ResponseWriter.WriteString(s)
+}
+
+func (w *timingWriter) Flush() {
+	w.stamp()
+	w.ResponseWriter.Flush()
+}
+
+func (w *timingWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+//💡 Server-Timing / X-Response-Time headers so the front-end sees backend latency in devtools
+func ServerTiming() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		orig := ctx.Writer
+		tw := &timingWriter{ResponseWriter: orig, start: time.Now()}
+		ctx.Writer = tw
+		defer func() { ctx.Writer = orig }()
+
+		ctx.Next()
+
+		// nothing written yet (e.g. bare ctx.Status), gin flushes the headers after us
+		if !orig.Written() {
+			tw.stamp()
+		}
+	}
+}
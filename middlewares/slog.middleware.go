@@ -0,0 +1,63 @@
+package middlewares
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/skyy/gin-gonic/ctxkeys"
+)
+
+//💡 SlogFormatter logs each request as a slog record, attribute names match logFormatLocal's
+// JSON keys so slog.NewJSONHandler output lines up with FormatLogsJSON. 5xx are logged at
+// Error, 4xx at Warn, the rest at Info. Requests to skipPaths aren't logged.
+//
+//	logger := slog.New(slog.NewJSONHandler(os.Stdout, nil))
+//	router.Use(middlewares.SlogFormatter(logger, middlewares.DefaultSkipPaths...))
+func SlogFormatter(logger *slog.Logger, skipPaths ...string) gin.HandlerFunc {
+	skip := make(map[string]bool, len(skipPaths))
+	for _, p := range skipPaths {
+		skip[p] = true
+	}
+
+	return func(ctx *gin.Context) {
+		start := time.Now()
+		path := ctx.Request.URL.Path
+		rawQuery := ctx.Request.URL.RawQuery
+
+		ctx.Next()
+
+		if skip[path] {
+			return
+		}
+		if rawQuery != "" {
+			path += "?" + redactQuery(rawQuery, RedactKeys)
+		}
+
+		latency := time.Since(start)
+		status := ctx.Writer.Status()
+		attrs := []slog.Attr{
+			slog.Int("status", status),
+			slog.String("client_ip", ctx.ClientIP()),
+			slog.String("method", ctx.Request.Method),
+			slog.String("path", path),
+			slog.Float64("latency_ms", durationMS(latency)),
+			slog.String("latency", latency.String()),
+			slog.String("proto", ctx.Request.Proto),
+			slog.String("error", ctx.Errors.ByType(gin.ErrorTypePrivate).String()),
+		}
+		if id := ctxkeys.GetRequestID(ctx); id != "" {
+			attrs = append(attrs, slog.String("request_id", id))
+		}
+
+		level := slog.LevelInfo
+		switch {
+		case status >= 500:
+			level = slog.LevelError
+		case status >= 400:
+			level = slog.LevelWarn
+		}
+		logger.LogAttrs(context.Background(), level, "request", attrs...)
+	}
+}
@@ -0,0 +1,44 @@
+package middlewares
+
+import (
+	"errors"
+	"net/http"
+	"regexp"
+
+	"github.com/gin-gonic/gin"
+	"github.com/skyy/gin-gonic/ctxkeys"
+)
+
+// tenant IDs end up in logs and storage keys, so keep them boring
+var validTenant = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]{0,62}$`)
+
+var ErrNoTenant = errors.New("no tenant in request")
+
+//💡 TenantResolver tags each request with the tenant resolve finds (subdomain, header,
+// JWT claim ...), for ctxkeys.GetTenant and the tenant_id field of the JSON logs.
+// Requests without a valid tenant (lowercase letters, digits, _ and -) get a 400.
+func TenantResolver(resolve func(*gin.Context) (string, error)) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		tenant, err := resolve(ctx)
+		if err == nil && !validTenant.MatchString(tenant) {
+			err = errors.New("invalid tenant id")
+		}
+		if err != nil {
+			abortWithError(ctx, http.StatusBadRequest, "tenant: " + err.Error())
+			return
+		}
+
+		ctxkeys.SetTenant(ctx, tenant)
+		ctx.Next()
+	}
+}
+
+// TenantFromHeader reads the tenant from header, e.g. X-Tenant-ID set by the gateway
+func TenantFromHeader(header string) func(*gin.Context) (string, error) {
+	return func(ctx *gin.Context) (string, error) {
+		if tenant := ctx.GetHeader(header); tenant != "" {
+			return tenant, nil
+		}
+		return "", ErrNoTenant
+	}
+}
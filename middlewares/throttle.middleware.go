@@ -0,0 +1,90 @@
+package middlewares
+
+import (
+	"math"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type failureCounter struct {
+	failures    int
+	windowStart time.Time
+	bannedUntil time.Time
+	lastSeen    time.Time
+}
+
+//💡 ThrottleOnFailure bans a client IP for ban once it collected threshold 401/403 responses
+// within window (credential stuffing / token guessing). Banned clients get a 429 without the
+// request reaching auth at all. A 2xx resets the count. Register it in front of the auth middleware.
+// Call the returned stop func on shutdown to end the cleanup goroutine (safe to call twice).
+func ThrottleOnFailure(threshold int, window, ban time.Duration) (gin.HandlerFunc, func()) {
+	var mu sync.Mutex
+	clients := map[string]*failureCounter{}
+
+	// forget clients that were quiet for a whole window and aren't banned, keeps memory bounded
+	done := make(chan struct{})
+	go func() {
+		ticker := time.NewTicker(window)
+		defer ticker.Stop()
+		for {
+			var now time.Time
+			select {
+			case <-done:
+				return
+			case now = <-ticker.C:
+			}
+			mu.Lock()
+			for ip, c := range clients {
+				if now.Sub(c.lastSeen) > window && now.After(c.bannedUntil) {
+					delete(clients, ip)
+				}
+			}
+			mu.Unlock()
+		}
+	}()
+
+	handler := func(ctx *gin.Context) {
+		ip := ctx.ClientIP()
+		now := time.Now()
+
+		mu.Lock()
+		c, ok := clients[ip]
+		if !ok {
+			c = &failureCounter{windowStart: now}
+			clients[ip] = c
+		}
+		c.lastSeen = now
+		bannedFor := c.bannedUntil.Sub(now)
+		mu.Unlock()
+
+		if bannedFor > 0 {
+			ctx.Header("Retry-After", strconv.Itoa(max(1, int(math.Ceil(bannedFor.Seconds())))))
+			abortWithError(ctx, http.StatusTooManyRequests, "too many failed attempts, try again later")
+			return
+		}
+
+		ctx.Next()
+
+		status := ctx.Writer.Status()
+		mu.Lock()
+		defer mu.Unlock()
+		switch {
+		case status == http.StatusUnauthorized || status == http.StatusForbidden:
+			if now.Sub(c.windowStart) > window {
+				c.failures, c.windowStart = 0, now
+			}
+			c.failures++
+			if c.failures >= threshold {
+				c.bannedUntil = now.Add(ban)
+				c.failures, c.windowStart = 0, now
+			}
+		case status >= 200 && status < 300:
+			c.failures, c.windowStart = 0, now
+		}
+	}
+	return handler, sync.OnceFunc(func() { close(done) })
+}
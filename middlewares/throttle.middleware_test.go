@@ -0,0 +1,18 @@
+package middlewares
+
+import (
+	"runtime"
+	"testing"
+	"time"
+)
+
+func TestThrottleOnFailureStop(t *testing.T) {
+	before := runtime.NumGoroutine()
+	_, stop := ThrottleOnFailure(1, time.Millisecond, time.Minute)
+
+	stop()
+	stop()
+	if !waitGoroutines(before) {
+		t.Errorf("%d goroutines after stop, want %d: the cleanup goroutine is still running", runtime.NumGoroutine(), before)
+	}
+}
@@ -0,0 +1,158 @@
+package middlewares
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/skyy/gin-gonic/i18n"
+)
+
+// timeoutWriter buffers the response so nothing reaches the client until the
+// handler finishes in time. Once timed out, later writes are dropped.
+type timeoutWriter struct {
+	gin.ResponseWriter
+	mu          sync.Mutex
+	header      http.Header
+	body        bytes.Buffer
+	status      int
+	wroteHeader bool
+	timedOut    bool
+}
+
+func (w *timeoutWriter) Header() http.Header {
+	return w.header
+}
+
+func (w *timeoutWriter) WriteHeader(code int) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.timedOut || w.wroteHeader {
+		return
+	}
+	w.status = code
+	w.wroteHeader = true
+}
+
+func (w *timeoutWriter) WriteHeaderNow() {}
+
+func (w *timeoutWriter) Write(b []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.timedOut {
+		return 0, http.ErrHandlerTimeout
+	}
+	w.wroteHeader = true
+	return w.body.Write(b)
+}
+
+func (w *timeoutWriter) WriteString(s string) (int, error) {
+	return w.Write([]byte(s))
+}
+
+func (w *timeoutWriter) Status() int {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.status
+}
+
+func (w *timeoutWriter) Size() int {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if !w.wroteHeader {
+		return -1
+	}
+	return w.body.Len()
+}
+
+func (w *timeoutWriter) Written() bool {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.wroteHeader
+}
+
+//💡 Per-route timeout. The rest of the chain runs in a goroutine with a
+// context.WithTimeout request context; if it isn't done after d the client gets
+// a 503 right away and whatever the slow handler writes afterwards is discarded.
+// The gin.Context is shared with that goroutine, so we still wait for it to
+// return before handing ctx back to gin: slow handlers should watch
+// ctx.Request.Context() and bail out early.
+func Timeout(d time.Duration) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		tctx, cancel := context.WithTimeout(ctx.Request.Context(), d)
+		defer cancel()
+		ctx.Request = ctx.Request.WithContext(tctx)
+		// translated up front, the timeout branch must not touch ctx while the handler runs
+		msg := i18n.Translate(lang(ctx), "request timed out")
+
+		orig := ctx.Writer
+		// starts as a copy of what earlier middlewares set (request ID, CORS ...), so the handler
+		// sees and can change them, and a timed-out handler can't touch the real ones
+		tw := &timeoutWriter{ResponseWriter: orig, header: orig.Header().Clone(), status: http.StatusOK}
+		ctx.Writer = tw
+
+		done := make(chan struct{})
+		panicked := make(chan any, 1)
+		go func() {
+			defer func() {
+				if p := recover(); p != nil {
+					panicked <- p
+					return
+				}
+				close(done)
+			}()
+			ctx.Next()
+		}()
+
+		select {
+		case p := <-panicked:
+			// re-panic on the request goroutine so RecoveryJSON sees it
+			ctx.Writer = orig
+			panic(p)
+
+		case <-done:
+			tw.mu.Lock()
+			defer tw.mu.Unlock()
+			ctx.Writer = orig
+			// replaced rather than merged, so headers the handler deleted stay deleted
+			h := orig.Header()
+			clear(h)
+			for k, v := range tw.header {
+				h[k] = v
+			}
+			if tw.wroteHeader {
+				orig.WriteHeader(tw.status)
+				orig.Write(tw.body.Bytes())
+			}
+
+		case <-tctx.Done():
+			tw.mu.Lock()
+			tw.timedOut = true
+			tw.status = http.StatusServiceUnavailable
+			tw.wroteHeader = true
+
+			// ctx still belongs to the handler goroutine, so write straight to the real writer
+			body, _ := json.Marshal(errorBody(http.StatusServiceUnavailable, msg))
+			orig.Header().Set("Content-Type", "application/json; charset=utf-8")
+			orig.Header().Set("Content-Length", strconv.Itoa(len(body)))
+			orig.WriteHeader(http.StatusServiceUnavailable)
+			orig.Write(body)
+			orig.Flush()
+			tw.mu.Unlock()
+
+			// the handler goroutine is done with ctx only now, so only now can Writer go back
+			select {
+			case p := <-panicked:
+				ctx.Writer = orig
+				panic(p)
+			case <-done:
+				ctx.Writer = orig
+			}
+		}
+	}
+}
@@ -0,0 +1,73 @@
+package middlewares
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestTimeoutHeaders(t *testing.T) {
+	tests := []struct {
+		name       string
+		handler    gin.HandlerFunc
+		wantStatus int
+		wantHeader map[string]string // "" = must be absent
+	}{
+		{
+			name: "in time: upstream headers visible, changes and deletions kept",
+			handler: func(ctx *gin.Context) {
+				if ctx.Writer.Header().Get("X-Request-ID") != "req-1" {
+					ctx.Status(http.StatusTeapot)
+					return
+				}
+				ctx.Header("X-Handler", "yes")
+				ctx.Writer.Header().Del("X-Upstream-Drop")
+				ctx.Status(http.StatusOK)
+			},
+			wantStatus: http.StatusOK,
+			wantHeader: map[string]string{"X-Request-ID": "req-1", "X-Handler": "yes", "X-Upstream-Drop": ""},
+		},
+		{
+			name: "timed out: upstream headers kept, the late handler's dropped",
+			handler: func(ctx *gin.Context) {
+				<-ctx.Request.Context().Done()
+				ctx.Header("X-Handler", "late")
+				ctx.Writer.Header().Del("X-Request-ID")
+			},
+			wantStatus: http.StatusServiceUnavailable,
+			wantHeader: map[string]string{"X-Request-ID": "req-1", "X-Upstream-Drop": "1", "X-Handler": ""},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var after gin.ResponseWriter
+			var before gin.ResponseWriter
+			r := gin.New()
+			r.GET("/", func(ctx *gin.Context) {
+				ctx.Header("X-Request-ID", "req-1")
+				ctx.Header("X-Upstream-Drop", "1")
+				before = ctx.Writer
+				ctx.Next()
+				after = ctx.Writer
+			}, Timeout(20*time.Millisecond), tt.handler)
+
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+
+			if w.Code != tt.wantStatus {
+				t.Errorf("status = %d, want %d", w.Code, tt.wantStatus)
+			}
+			for k, want := range tt.wantHeader {
+				if got := w.Header().Get(k); got != want {
+					t.Errorf("%s = %q, want %q", k, got, want)
+				}
+			}
+			if after != before {
+				t.Errorf("ctx.Writer is %T after Timeout, want the original writer back", after)
+			}
+		})
+	}
+}
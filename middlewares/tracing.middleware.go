@@ -0,0 +1,55 @@
+package middlewares
+
+import (
+	"fmt"
+
+	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// W3C traceparent / tracestate, regardless of what the global propagator is set to
+var traceContext = propagation.TraceContext{}
+
+//💡 Tracing starts one OTel span per request, continuing the caller's trace when a
+// traceparent header is present. Handlers get the span through ctx.Request.Context(),
+// so outgoing calls made with it join the same trace. Spans go to the global
+// TracerProvider (a no-op until main installs an exporter via otel.SetTracerProvider).
+func Tracing(tracerName string) gin.HandlerFunc {
+	tracer := otel.Tracer(tracerName)
+
+	return func(ctx *gin.Context) {
+		parent := traceContext.Extract(ctx.Request.Context(), propagation.HeaderCarrier(ctx.Request.Header))
+
+		route := ctx.FullPath()
+		if route == "" {
+			route = "unmatched" // same label as PrometheusMiddleware, keeps span names low-cardinality
+		}
+		spanCtx, span := tracer.Start(parent, ctx.Request.Method+" "+route,
+			trace.WithSpanKind(trace.SpanKindServer),
+			trace.WithAttributes(
+				attribute.String("http.request.method", ctx.Request.Method),
+				attribute.String("http.route", route),
+				attribute.String("url.path", ctx.Request.URL.Path),
+				attribute.String("client.address", ctx.ClientIP()),
+			),
+		)
+		defer span.End()
+
+		ctx.Request = ctx.Request.WithContext(spanCtx)
+		ctx.Next()
+
+		status := ctx.Writer.Status()
+		span.SetAttributes(attribute.Int("http.response.status_code", status))
+		if len(ctx.Errors) > 0 {
+			span.RecordError(ctx.Errors.Last())
+		}
+		// server spans only count 5xx as errors, a 4xx is the client's fault
+		if status >= 500 {
+			span.SetStatus(codes.Error, fmt.Sprintf("HTTP %d", status))
+		}
+	}
+}
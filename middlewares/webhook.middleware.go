@@ -0,0 +1,109 @@
+package middlewares
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// how far ahead of our clock a sender's timestamp may be (their clock runs fast)
+const timestampTolerance = 30 * time.Second
+
+//💡 VerifyWebhook checks the HMAC-SHA256 of the raw body against the signature in header
+// (hex or base64, an optional "sha256=" prefix is fine), 401 if it's missing or wrong.
+// The body is put back afterwards, so the handler can still read it.
+// With a timestampHeader the signed content is "<timestamp>.<body>", so the timestamp
+// RequireFreshTimestamp checks can't be swapped on a captured request.
+func VerifyWebhook(secret []byte, header string, timestampHeader ...string) gin.HandlerFunc {
+	if len(secret) == 0 {
+		panic("⚠️VerifyWebhook: empty secret")
+	}
+
+	return func(ctx *gin.Context) {
+		sig, ok := decodeSignature(ctx.GetHeader(header))
+		if !ok {
+			abortWebhook(ctx, "missing or malformed signature")
+			return
+		}
+
+		var body []byte
+		if ctx.Request.Body != nil {
+			var err error
+			if body, err = io.ReadAll(ctx.Request.Body); err != nil {
+				abortWithError(ctx, http.StatusBadRequest, "failed to read request body")
+				return
+			}
+		}
+		ctx.Request.Body = io.NopCloser(bytes.NewReader(body))
+
+		mac := hmac.New(sha256.New, secret)
+		if len(timestampHeader) > 0 {
+			mac.Write([]byte(ctx.GetHeader(timestampHeader[0]) + "."))
+		}
+		mac.Write(body)
+		if !hmac.Equal(mac.Sum(nil), sig) {
+			abortWebhook(ctx, "invalid signature")
+			return
+		}
+
+		ctx.Next()
+	}
+}
+
+// the clock RequireFreshTimestamp compares against, tests pin it
+var webhookNow = time.Now
+
+//💡 RequireFreshTimestamp rejects (401) requests whose header (unix seconds, e.g. X-Timestamp)
+// is missing, older than maxSkew or more than 30s in the future. Put it in front of
+// VerifyWebhook with the same header, a replayed request then only works within maxSkew.
+func RequireFreshTimestamp(header string, maxSkew time.Duration) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		secs, err := strconv.ParseInt(strings.TrimSpace(ctx.GetHeader(header)), 10, 64)
+		if err != nil {
+			abortWebhook(ctx, "missing or malformed "+header)
+			return
+		}
+
+		age := webhookNow().Sub(time.Unix(secs, 0))
+		switch {
+		case age > maxSkew:
+			abortWebhook(ctx, "request is too old")
+			return
+		case age < -timestampTolerance:
+			abortWebhook(ctx, "request timestamp is in the future")
+			return
+		}
+
+		ctx.Next()
+	}
+}
+
+// hex is tried first: a 64 char hex string is also valid base64, but never the right length for it
+func decodeSignature(value string) ([]byte, bool) {
+	value = strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(value), "sha256="))
+	if value == "" {
+		return nil, false
+	}
+	if sig, err := hex.DecodeString(value); err == nil && len(sig) == sha256.Size {
+		return sig, true
+	}
+	for _, enc := range []*base64.Encoding{base64.StdEncoding, base64.RawStdEncoding, base64.URLEncoding, base64.RawURLEncoding} {
+		if sig, err := enc.DecodeString(value); err == nil && len(sig) == sha256.Size {
+			return sig, true
+		}
+	}
+	return nil, false
+}
+
+func abortWebhook(ctx *gin.Context, msg string) {
+	abortWithError(ctx, http.StatusUnauthorized, msg)
+}
@@ -0,0 +1,118 @@
+package middlewares
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// pins the clock RequireFreshTimestamp uses for the rest of the test
+func fixClock(t *testing.T, now time.Time) {
+	t.Helper()
+	prev := webhookNow
+	webhookNow = func() time.Time { return now }
+	t.Cleanup(func() { webhookNow = prev })
+}
+
+func TestRequireFreshTimestamp(t *testing.T) {
+	const maxSkew = 5 * time.Minute
+	now := time.Unix(1_800_000_000, 0)
+	fixClock(t, now)
+
+	ts := func(offset time.Duration) string {
+		return strconv.FormatInt(now.Add(offset).Unix(), 10)
+	}
+	tests := []struct {
+		name      string
+		timestamp string
+		want      int
+	}{
+		{"now", ts(0), http.StatusOK},
+		{"just inside maxSkew", ts(-maxSkew + time.Second), http.StatusOK},
+		{"exactly maxSkew old", ts(-maxSkew), http.StatusOK},
+		{"just outside maxSkew", ts(-maxSkew - time.Second), http.StatusUnauthorized},
+		{"just inside the future tolerance", ts(timestampTolerance), http.StatusOK},
+		{"just outside the future tolerance", ts(timestampTolerance + time.Second), http.StatusUnauthorized},
+		{"far future", strconv.FormatInt(1<<62, 10), http.StatusUnauthorized},
+		{"epoch", "0", http.StatusUnauthorized},
+		{"surrounding spaces", " " + ts(0) + " ", http.StatusOK},
+		{"missing", "", http.StatusUnauthorized},
+		{"milliseconds", strconv.FormatInt(now.UnixMilli(), 10), http.StatusUnauthorized},
+		{"not a number", "yesterday", http.StatusUnauthorized},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := gin.New()
+			r.POST("/webhooks", RequireFreshTimestamp("X-Timestamp", maxSkew), func(ctx *gin.Context) {
+				ctx.Status(http.StatusOK)
+			})
+			req := httptest.NewRequest(http.MethodPost, "/webhooks", nil)
+			if tt.timestamp != "" {
+				req.Header.Set("X-Timestamp", tt.timestamp)
+			}
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			if w.Code != tt.want {
+				t.Errorf("X-Timestamp %q: status = %d, want %d (body %s)", tt.timestamp, w.Code, tt.want, w.Body)
+			}
+		})
+	}
+}
+
+// a captured, validly signed request is only good for maxSkew, and its timestamp can't be
+// bumped without breaking the signature
+func TestFreshTimestampWithSignature(t *testing.T) {
+	const maxSkew = 5 * time.Minute
+	secret := []byte("webhook-secret")
+	body := `{"event":"paid"}`
+	sent := time.Unix(1_800_000_000, 0)
+	sentTS := strconv.FormatInt(sent.Unix(), 10)
+
+	sign := func(ts string) string {
+		mac := hmac.New(sha256.New, secret)
+		mac.Write([]byte(ts + "." + body))
+		return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+	}
+
+	tests := []struct {
+		name      string
+		now       time.Time
+		timestamp string
+		signature string
+		want      int
+	}{
+		{"delivered right away", sent.Add(2 * time.Second), sentTS, sign(sentTS), http.StatusOK},
+		{"replayed within maxSkew", sent.Add(maxSkew - time.Second), sentTS, sign(sentTS), http.StatusOK},
+		{"replayed after maxSkew", sent.Add(maxSkew + time.Second), sentTS, sign(sentTS), http.StatusUnauthorized},
+		{"replayed with a bumped timestamp", sent.Add(time.Hour), strconv.FormatInt(sent.Add(time.Hour).Unix(), 10), sign(sentTS), http.StatusUnauthorized},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fixClock(t, tt.now)
+			r := gin.New()
+			r.POST("/webhooks",
+				RequireFreshTimestamp("X-Timestamp", maxSkew),
+				VerifyWebhook(secret, "X-Signature", "X-Timestamp"),
+				func(ctx *gin.Context) { ctx.Status(http.StatusOK) })
+
+			req := httptest.NewRequest(http.MethodPost, "/webhooks", strings.NewReader(body))
+			req.Header.Set("X-Timestamp", tt.timestamp)
+			req.Header.Set("X-Signature", tt.signature)
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			if w.Code != tt.want {
+				t.Errorf("status = %d, want %d (body %s)", w.Code, tt.want, w.Body)
+			}
+		})
+	}
+}
@@ -0,0 +1,95 @@
+package routes
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+// Security schemes a Route can declare, named as in the generated components.securitySchemes
+const (
+	SecurityBearer = "bearerAuth" // Authorization: Bearer <JWT>, see auth/jwt
+	SecurityBasic  = "basicAuth"
+	SecurityAPIKey = "apiKeyAuth" // X-API-Key
+)
+
+var securitySchemes = map[string]map[string]string{
+	SecurityBearer: {"type": "http", "scheme": "bearer", "bearerFormat": "JWT"},
+	SecurityBasic:  {"type": "http", "scheme": "basic"},
+	SecurityAPIKey: {"type": "apiKey", "in": "header", "name": "X-API-Key"},
+}
+
+type openAPIParam struct {
+	Name     string            `json:"name"`
+	In       string            `json:"in"`
+	Required bool              `json:"required"`
+	Schema   map[string]string `json:"schema"`
+}
+
+type openAPIOperation struct {
+	OperationID string                       `json:"operationId"`
+	Parameters  []openAPIParam               `json:"parameters,omitempty"`
+	Security    []map[string][]string        `json:"security,omitempty"`
+	Responses   map[string]map[string]string `json:"responses"`
+}
+
+//💡 GenerateOpenAPI builds a minimal OpenAPI 3.0 document from the route table: paths,
+// methods, path params (":id" -> "{id}") and each route's security scheme. No request or
+// response schemas, it's a map of the API rather than a full contract.
+func GenerateOpenAPI(routes []Route) ([]byte, error) {
+	paths := map[string]map[string]openAPIOperation{}
+	used := map[string]any{}
+
+	for _, r := range routes {
+		apiPath, params := openAPIPath(r.FullPath())
+		op := openAPIOperation{
+			OperationID: operationID(r.Method, apiPath),
+			Parameters:  params,
+			Responses:   map[string]map[string]string{"default": {"description": "Default response"}},
+		}
+		if scheme, ok := securitySchemes[r.Security]; ok {
+			op.Security = []map[string][]string{{r.Security: {}}}
+			used[r.Security] = scheme
+		}
+
+		if paths[apiPath] == nil {
+			paths[apiPath] = map[string]openAPIOperation{}
+		}
+		paths[apiPath][strings.ToLower(r.Method)] = op
+	}
+
+	doc := map[string]any{
+		"openapi": "3.0.3",
+		"info":    map[string]string{"title": "gin-gonic API", "version": "1.0.0"},
+		"paths":   paths,
+	}
+	if len(used) > 0 {
+		doc["components"] = map[string]any{"securitySchemes": used}
+	}
+	return json.MarshalIndent(doc, "", "  ")
+}
+
+// "/admin/get-UrlParams/:name/:age" -> "/admin/get-UrlParams/{name}/{age}" + both as path params
+func openAPIPath(fullPath string) (string, []openAPIParam) {
+	var params []openAPIParam
+	segments := strings.Split(fullPath, "/")
+	for i, seg := range segments {
+		if seg == "" || (seg[0] != ':' && seg[0] != '*') {
+			continue
+		}
+		name := seg[1:]
+		segments[i] = "{" + name + "}"
+		params = append(params, openAPIParam{
+			Name:     name,
+			In:       "path",
+			Required: true,
+			Schema:   map[string]string{"type": "string"},
+		})
+	}
+	return strings.Join(segments, "/"), params
+}
+
+// "GET", "/admin/users/{id}" -> "get_admin_users_id"
+func operationID(method, apiPath string) string {
+	id := strings.ToLower(method) + "_" + strings.Trim(apiPath, "/")
+	return strings.NewReplacer("/", "_", "{", "", "}", "", "-", "_").Replace(id)
+}
@@ -0,0 +1,139 @@
+// Package routes registers handlers from a declarative table, so every route
+// (and the middlewares in front of it) can be read in one place.
+package routes
+
+import (
+	"errors"
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Route is one table entry. Path is relative to Group ("" = the engine itself),
+// Middlewares run after the group's, just in front of Handler.
+type Route struct {
+	Method      string
+	Path        string
+	Handler     gin.HandlerFunc
+	Middlewares []gin.HandlerFunc
+	Group       string
+	Security    string // scheme guarding it, one of the Security* constants ("" = public), for GenerateOpenAPI
+}
+
+// InGroup sets Group and Security on every route in rs, so a table section doesn't repeat them
+func InGroup(prefix, security string, rs []Route) []Route {
+	for i := range rs {
+		rs[i].Group = prefix
+		rs[i].Security = security
+	}
+	return rs
+}
+
+// Group declares the middlewares shared by every route whose Group is Prefix
+type Group struct {
+	Prefix      string
+	Middlewares []gin.HandlerFunc
+}
+
+//💡 RegisterRoutes attaches routes to router in table order. A Group prefix used by a route
+// but missing from groups gets a plain router.Group without extra middlewares.
+// Duplicate method + path pairs (in the table, or already on the router) are reported as
+// an error naming both entries before anything is registered, instead of gin's startup panic.
+//
+//	err := routes.RegisterRoutes(router, []routes.Route{
+//		{Group: "/admin", Method: http.MethodGet, Path: "/users", Handler: users.List},
+//	}, routes.Group{Prefix: "/admin", Middlewares: adminStack})
+func RegisterRoutes(router *gin.Engine, routes []Route, groups ...Group) error {
+	if err := checkDuplicates(router, routes); err != nil {
+		return err
+	}
+
+	built := map[string]*gin.RouterGroup{"": &router.RouterGroup}
+	for _, g := range groups {
+		built[g.Prefix] = router.Group(g.Prefix, g.Middlewares...)
+	}
+
+	for _, r := range routes {
+		group, ok := built[r.Group]
+		if !ok {
+			group = router.Group(r.Group)
+			built[r.Group] = group
+		}
+
+		handlers := make([]gin.HandlerFunc, 0, len(r.Middlewares)+1)
+		handlers = append(handlers, r.Middlewares...)
+		handlers = append(handlers, r.Handler)
+		if err := safeRegister(group, r, handlers); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// FullPath is the path the route ends up at, e.g. Group "/admin" + Path "/users/:id"
+func (r Route) FullPath() string {
+	return joinPaths(r.Group, r.Path)
+}
+
+func (r Route) String() string {
+	return r.Method + " " + r.FullPath()
+}
+
+// two routes clash when they only differ in wildcard names (/users/:id vs /users/:name)
+func routeKey(method, fullPath string) string {
+	segments := strings.Split(fullPath, "/")
+	for i, seg := range segments {
+		if seg != "" && (seg[0] == ':' || seg[0] == '*') {
+			segments[i] = seg[:1]
+		}
+	}
+	return method + " " + strings.Join(segments, "/")
+}
+
+func checkDuplicates(router *gin.Engine, routes []Route) error {
+	seen := map[string]string{}
+	for _, existing := range router.Routes() {
+		seen[routeKey(existing.Method, existing.Path)] = existing.Method + " " + existing.Path + " (registered outside the table)"
+	}
+
+	var errs []error
+	for i, r := range routes {
+		key := routeKey(r.Method, r.FullPath())
+		where := fmt.Sprintf("%s (table entry %d)", r, i)
+		if prev, ok := seen[key]; ok {
+			errs = append(errs, fmt.Errorf("routes: duplicate route %s conflicts with %s", where, prev))
+			continue
+		}
+		seen[key] = where
+	}
+	return errors.Join(errs...)
+}
+
+// gin still panics on conflicts we don't detect (e.g. /users/new next to /users/*rest),
+// turn those into an error naming the route too
+func safeRegister(group *gin.RouterGroup, r Route, handlers []gin.HandlerFunc) (err error) {
+	defer func() {
+		if rec := recover(); rec != nil {
+			err = fmt.Errorf("routes: can't register %s: %v", r, rec)
+		}
+	}()
+	group.Handle(r.Method, r.Path, handlers...)
+	return nil
+}
+
+// same joining rules as gin's RouterGroup (a trailing slash on relative is kept)
+func joinPaths(absolute, relative string) string {
+	if absolute == "" {
+		absolute = "/"
+	}
+	if relative == "" {
+		return absolute
+	}
+	joined := path.Join(absolute, relative)
+	if strings.HasSuffix(relative, "/") && !strings.HasSuffix(joined, "/") {
+		return joined + "/"
+	}
+	return joined
+}
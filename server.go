@@ -0,0 +1,14 @@
+package main
+
+import (
+	"net/http"
+	"time"
+
+	"golang.org/x/net/http2"
+	"golang.org/x/net/http2/h2c"
+)
+
+// withH2C serves HTTP/2 without TLS (prior knowledge or Upgrade: h2c) next to plain HTTP/1.1
+func withH2C(handler http.Handler, idleTimeout time.Duration) http.Handler {
+	return h2c.NewHandler(handler, &http2.Server{IdleTimeout: idleTimeout})
+}
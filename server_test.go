@@ -0,0 +1,73 @@
+package main
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/skyy/gin-gonic/handlers"
+	"golang.org/x/net/http2"
+)
+
+func newH2CServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	router := gin.New()
+	router.GET("/", handlers.RootHandler)
+	srv := httptest.NewServer(withH2C(router, time.Minute))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func getRoot(t *testing.T, client *http.Client, url string) (*http.Response, map[string]any) {
+	t.Helper()
+	resp, err := client.Get(url + "/")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	var body map[string]any
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		t.Fatalf("decoding RootHandler's body: %v", err)
+	}
+	return resp, body
+}
+
+func TestH2CReachesRootHandler(t *testing.T) {
+	srv := newH2CServer(t)
+
+	// prior-knowledge h2c: HTTP/2 frames straight over the plain TCP connection
+	client := &http.Client{Transport: &http2.Transport{
+		AllowHTTP: true,
+		DialTLSContext: func(ctx context.Context, network, addr string, _ *tls.Config) (net.Conn, error) {
+			var d net.Dialer
+			return d.DialContext(ctx, network, addr)
+		},
+	}}
+	resp, body := getRoot(t, client, srv.URL)
+
+	if resp.ProtoMajor != 2 {
+		t.Errorf("proto = %s, want HTTP/2", resp.Proto)
+	}
+	if resp.StatusCode != http.StatusOK || body["data"] == nil {
+		t.Errorf("status = %d, body %v, want RootHandler's 200", resp.StatusCode, body)
+	}
+}
+
+func TestH2CKeepsHTTP1(t *testing.T) {
+	srv := newH2CServer(t)
+
+	resp, body := getRoot(t, srv.Client(), srv.URL)
+
+	if resp.ProtoMajor != 1 {
+		t.Errorf("proto = %s, want HTTP/1.x", resp.Proto)
+	}
+	if resp.StatusCode != http.StatusOK || body["data"] == nil {
+		t.Errorf("status = %d, body %v, want RootHandler's 200", resp.StatusCode, body)
+	}
+}
@@ -0,0 +1,39 @@
+package main
+
+import (
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/skyy/gin-gonic/auth/jwt"
+	"github.com/skyy/gin-gonic/middlewares"
+)
+
+// Route group stacks, first = outermost (see middlewares.Chain for the ordering rules).
+// They live outside main so the ordering tests run the exact chains main registers.
+
+// what Respond can render, best first; anything else is a 406
+func acceptAPI() gin.HandlerFunc {
+	return middlewares.AcceptFilter("application/json", "application/xml", "text/xml", "application/yaml", "application/x-yaml")
+}
+
+// /admin: failed-login throttle, rate limit, JWT + admin role, audit, deadline for downstream calls
+func newAdminStack(throttle, limit gin.HandlerFunc, jwtSecret []byte, audit middlewares.AuditSink, deadline time.Duration) gin.HandlersChain {
+	return middlewares.Chain(
+		throttle,
+		limit,
+		jwt.JWTAuth(jwtSecret),
+		middlewares.RequireRole("admin"),
+		middlewares.AuditLog(audit), // after auth, so it knows the principal
+		middlewares.WithDeadline(deadline),
+		acceptAPI(),
+	)
+}
+
+// /client: API key, then content negotiation and a 5s Timeout.
+// stream is for SSE & co.: they run for as long as the client stays, so no Timeout.
+func newClientStacks(keys middlewares.KeyStore) (client, stream gin.HandlersChain) {
+	apiKeyAuth := middlewares.APIKeyAuth(keys)
+	client = middlewares.Chain(apiKeyAuth, acceptAPI(), middlewares.Timeout(5*time.Second))
+	stream = middlewares.Chain(apiKeyAuth)
+	return client, stream
+}
@@ -0,0 +1,138 @@
+package store
+
+import (
+	"cmp"
+	"errors"
+	"slices"
+	"strings"
+	"sync"
+)
+
+var (
+	ErrNotFound  = errors.New("store: user not found")
+	ErrDuplicate = errors.New("store: user already exists")
+)
+
+type User struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+	Age  int    `json:"age"`
+}
+
+//💡 In-memory, thread-safe user store (map + RWMutex)
+type UserStore struct {
+	mu    sync.RWMutex
+	users map[string]User
+}
+
+func NewUserStore() *UserStore {
+	return &UserStore{users: make(map[string]User)}
+}
+
+func (s *UserStore) Create(u User) (User, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.users[u.ID]; ok {
+		return User{}, ErrDuplicate
+	}
+	s.users[u.ID] = u
+	return u, nil
+}
+
+func (s *UserStore) Get(id string) (User, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	u, ok := s.users[id]
+	if !ok {
+		return User{}, ErrNotFound
+	}
+	return u, nil
+}
+
+// Update replaces the stored user, the ID can't change
+func (s *UserStore) Update(id string, u User) (User, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.users[id]; !ok {
+		return User{}, ErrNotFound
+	}
+	u.ID = id
+	s.users[id] = u
+	return u, nil
+}
+
+// Patch runs apply on a copy of the stored user and saves the result, all under the
+// write lock so concurrent patches to different fields don't lose each other's changes.
+// An error from apply leaves the user untouched. The ID can't be changed.
+func (s *UserStore) Patch(id string, apply func(u *User) error) (User, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	u, ok := s.users[id]
+	if !ok {
+		return User{}, ErrNotFound
+	}
+	if err := apply(&u); err != nil {
+		return User{}, err
+	}
+	u.ID = id
+	s.users[id] = u
+	return u, nil
+}
+
+func (s *UserStore) Delete(id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.users[id]; !ok {
+		return ErrNotFound
+	}
+	delete(s.users, id)
+	return nil
+}
+
+// List returns one page of users ordered by ID, plus the total count
+func (s *UserStore) List(limit, offset int) ([]User, int) {
+	return s.ListSorted("id", false, limit, offset)
+}
+
+// ListSorted is List ordered by field ("id", "name" or "age", anything else sorts by id).
+// Ties are broken by id so pages stay stable.
+func (s *UserStore) ListSorted(field string, desc bool, limit, offset int) ([]User, int) {
+	s.mu.RLock()
+	all := make([]User, 0, len(s.users))
+	for _, u := range s.users {
+		all = append(all, u)
+	}
+	s.mu.RUnlock()
+
+	byField := func(a, b User) int {
+		switch field {
+		case "name":
+			return strings.Compare(a.Name, b.Name)
+		case "age":
+			return cmp.Compare(a.Age, b.Age)
+		}
+		return 0
+	}
+	slices.SortFunc(all, func(a, b User) int {
+		c := byField(a, b)
+		if c == 0 {
+			c = strings.Compare(a.ID, b.ID)
+		}
+		if desc {
+			return -c
+		}
+		return c
+	})
+
+	total := len(all)
+	if offset < 0 || offset >= total || limit < 1 {
+		return []User{}, total
+	}
+	end := offset + min(limit, total-offset) // offset+limit could overflow
+	return all[offset:end], total
+}
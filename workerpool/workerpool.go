@@ -0,0 +1,90 @@
+package workerpool
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/sirupsen/logrus"
+)
+
+var (
+	ErrQueueFull = errors.New("workerpool: queue is full")
+	ErrClosed    = errors.New("workerpool: pool is shut down")
+)
+
+//💡 Pool runs submitted tasks on a fixed number of goroutines with a bounded queue,
+// so slow work (reports, emails ...) never grows into one goroutine per request.
+// Submit doesn't block: a full queue is an error the caller turns into a 503.
+type Pool struct {
+	mu     sync.RWMutex // guards closed vs. sends on tasks
+	closed bool
+	tasks  chan func()
+	wg     sync.WaitGroup
+}
+
+// New starts workers goroutines sharing a queue of up to queueSize waiting tasks
+func New(workers, queueSize int) *Pool {
+	p := &Pool{tasks: make(chan func(), queueSize)}
+	p.wg.Add(workers)
+	for range workers {
+		go p.work()
+	}
+	return p
+}
+
+func (p *Pool) work() {
+	defer p.wg.Done()
+	for task := range p.tasks {
+		run(task)
+	}
+}
+
+// a panicking task must not take its worker (or the process) down with it
+func run(task func()) {
+	defer func() {
+		if r := recover(); r != nil {
+			logrus.WithField("panic", r).Errorln("⚠️workerpool task panicked")
+		}
+	}()
+	task()
+}
+
+// Submit queues task, or returns ErrQueueFull / ErrClosed right away
+func (p *Pool) Submit(task func()) error {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	if p.closed {
+		return ErrClosed
+	}
+	select {
+	case p.tasks <- task:
+		return nil
+	default:
+		return ErrQueueFull
+	}
+}
+
+// Shutdown stops accepting tasks and waits for the queued and running ones to finish,
+// or for ctx to end (tasks still running are then abandoned).
+func (p *Pool) Shutdown(ctx context.Context) error {
+	p.mu.Lock()
+	if !p.closed {
+		p.closed = true
+		close(p.tasks)
+	}
+	p.mu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		p.wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}